@@ -0,0 +1,127 @@
+package audio
+
+import (
+	"fmt"
+	"path/filepath"
+	"simpleplatformer/constants"
+
+	"github.com/veandco/go-sdl2/mix"
+)
+
+type Sound int
+
+const (
+	Jump Sound = iota
+	Swoosh
+	Hit
+	Death
+	Alarm
+	Land
+	LadderStep
+	Hiss
+	Block
+)
+
+// soundFiles are the files of the sounds in the sounds directory, indexed by Sound
+var soundFiles = []string{"jump.wav", "swoosh.wav", "hit.wav", "death.wav", "alarm.wav", "land.wav", "ladder.wav", "hiss.wav", "block.wav"}
+
+// Backend plays the game sounds and music
+type Backend interface {
+	PlaySound(s Sound)
+	// PlaySoundAt plays the sound made by the emitter, relative to the listener. It follows the emitter while it plays.
+	PlaySoundAt(s Sound, e Emitter)
+	SetListener(x, y int32)
+	PlayMusic(track string)
+	SetDucked(ducked bool)
+	SetVolume(music, sound int)
+	// Update is called every frame to switch and loop the music tracks and move the positional sounds with their emitters
+	Update()
+	Close()
+}
+
+// NullBackend plays nothing, it's used when there is no audio device (e.g. in headless tests)
+type NullBackend struct{}
+
+func (NullBackend) PlaySound(Sound) {}
+
+func (NullBackend) PlaySoundAt(Sound, Emitter) {}
+
+func (NullBackend) SetListener(int32, int32) {}
+
+func (NullBackend) PlayMusic(string) {}
+
+func (NullBackend) SetDucked(bool) {}
+
+func (NullBackend) SetVolume(int, int) {}
+
+func (NullBackend) Update() {}
+
+func (NullBackend) Close() {}
+
+// mixerBackend plays sounds and music with SDL_mixer
+type mixerBackend struct {
+	chunks       []*mix.Chunk
+	tracks       map[string]*track
+	currentTrack string
+	// streamedTrack is the track on the music stream, nextTrack the one waiting for it to fade out
+	streamedTrack *track
+	nextTrack     *track
+	musicVolume   int
+	soundVolume   int
+	ducked        bool
+	listenerX     int32
+	listenerY     int32
+	// emitters make the positional sounds playing on the channels
+	emitters map[int]Emitter
+}
+
+// NewMixerBackend opens the audio device, loads all the sounds from soundsDir and the tracks listed in musicDir
+func NewMixerBackend(soundsDir, musicDir string) (Backend, error) {
+	// OGG support is optional, WAV music plays without it
+	mix.Init(mix.INIT_OGG)
+	if err := mix.OpenAudio(mix.DEFAULT_FREQUENCY, mix.DEFAULT_FORMAT, mix.DEFAULT_CHANNELS, mix.DEFAULT_CHUNKSIZE); err != nil {
+		return nil, fmt.Errorf("could not open audio: %v", err)
+	}
+	mix.AllocateChannels(constants.AudioChannels)
+	// Sounds are played on the first free channel, they must not take the stinger one
+	mix.ReserveChannels(constants.StingerChannel + 1)
+	b := &mixerBackend{tracks: map[string]*track{}, emitters: map[int]Emitter{}, musicVolume: constants.VolumeSteps, soundVolume: constants.VolumeSteps}
+	for _, name := range soundFiles {
+		chunk, err := mix.LoadWAV(filepath.Join(soundsDir, name))
+		if err != nil {
+			b.Close()
+			return nil, fmt.Errorf("could not load sound %v: %v", name, err)
+		}
+		b.chunks = append(b.chunks, chunk)
+	}
+	tracks, err := loadTracks(musicDir)
+	if err != nil {
+		b.Close()
+		return nil, err
+	}
+	b.tracks = tracks
+	return b, nil
+}
+
+func (b *mixerBackend) PlaySound(s Sound) {
+	channel, err := b.chunks[s].Play(-1, 0)
+	if err != nil {
+		// Sounds are skipped when all the channels are busy
+		return
+	}
+	// Clears the effects left on the channel by a positional sound
+	delete(b.emitters, channel)
+	mix.SetPanning(channel, 255, 255)
+	mix.SetDistance(channel, 0)
+}
+
+func (b *mixerBackend) Close() {
+	mix.HaltMusic()
+	mix.HaltChannel(-1)
+	freeTracks(b.tracks)
+	for _, chunk := range b.chunks {
+		chunk.Free()
+	}
+	mix.CloseAudio()
+	mix.Quit()
+}
@@ -0,0 +1,168 @@
+package audio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"simpleplatformer/constants"
+
+	"github.com/veandco/go-sdl2/mix"
+)
+
+// Tracks played in the scenes, levels can choose their own gameplay tracks
+const (
+	TitleMusic    = "title"
+	GameplayMusic = "gameplay"
+	BossMusic     = "boss"
+	GameOverMusic = "gameover"
+)
+
+// trackData describes a music file listed in the tracks manifest
+type trackData struct {
+	File string `json:"file"`
+	// LoopStart is the position in seconds the track continues from when it ends, it needs a format the mixer can seek in
+	LoopStart float64 `json:"loopStart"`
+	// Once tracks are not looped
+	Once bool `json:"once"`
+	// Stinger tracks are short, they are loaded into memory and played on their own channel to crossfade with the music
+	Stinger bool `json:"stinger"`
+}
+
+// track is either streamed from its file while played or a stinger played as a chunk
+type track struct {
+	music *mix.Music
+	chunk *mix.Chunk
+	data  trackData
+}
+
+// loadTracks opens all the tracks listed in the manifest, tracks sharing a file share the loaded music too
+func loadTracks(dir string) (map[string]*track, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, "tracks.json"))
+	if err != nil {
+		return nil, fmt.Errorf("could not read tracks manifest: %v", err)
+	}
+	manifest := map[string]trackData{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("could not parse tracks manifest: %v", err)
+	}
+	tracks := map[string]*track{}
+	musics := map[string]*mix.Music{}
+	chunks := map[string]*mix.Chunk{}
+	for name, td := range manifest {
+		t := &track{data: td, music: musics[td.File], chunk: chunks[td.File]}
+		path := filepath.Join(dir, td.File)
+		if td.Stinger && t.chunk == nil {
+			t.chunk, err = mix.LoadWAV(path)
+			chunks[td.File] = t.chunk
+		} else if !td.Stinger && t.music == nil {
+			t.music, err = mix.LoadMUS(path)
+			musics[td.File] = t.music
+		}
+		if err != nil {
+			freeTracks(tracks)
+			return nil, fmt.Errorf("could not load music %v: %v", td.File, err)
+		}
+		tracks[name] = t
+	}
+	return tracks, nil
+}
+
+// freeTracks frees the music of all the tracks, the shared files only once
+func freeTracks(tracks map[string]*track) {
+	freed := map[string]bool{}
+	for _, t := range tracks {
+		if freed[t.data.File] {
+			continue
+		}
+		freed[t.data.File] = true
+		if t.music != nil {
+			t.music.Free()
+		}
+		if t.chunk != nil {
+			t.chunk.Free()
+		}
+	}
+}
+
+// PlayMusic switches to the given track, playing the track already on does nothing.
+// A stinger crossfades with the music, as it plays on its own channel. There is a single music stream,
+// so a streamed track starts once the one before it has faded out.
+func (b *mixerBackend) PlayMusic(name string) {
+	if name == b.currentTrack {
+		return
+	}
+	b.currentTrack = name
+	if mix.Playing(constants.StingerChannel) != 0 {
+		mix.FadeOutChannel(constants.StingerChannel, constants.MusicFadeTime)
+	}
+	t, ok := b.tracks[name]
+	if !ok || t.data.Stinger {
+		// The music fading out is not looped again
+		b.streamedTrack, b.nextTrack = nil, nil
+	} else {
+		b.nextTrack = t
+	}
+	if mix.PlayingMusic() {
+		mix.FadeOutMusic(constants.MusicFadeTime)
+	}
+	if ok && t.data.Stinger {
+		loops := -1
+		if t.data.Once {
+			loops = 0
+		}
+		if _, err := t.chunk.FadeIn(constants.StingerChannel, loops, constants.MusicFadeTime); err != nil {
+			log.Printf("could not play music %v: %v", name, err)
+		}
+	}
+}
+
+// Update starts the next streamed track after the previous one faded out and loops the current one.
+// The positional sounds are panned again, as their emitters and the listener move.
+func (b *mixerBackend) Update() {
+	b.updateEmitters()
+	if mix.PlayingMusic() {
+		return
+	}
+	if t := b.nextTrack; t != nil {
+		b.streamedTrack, b.nextTrack = t, nil
+		t.music.FadeIn(1, constants.MusicFadeTime)
+		return
+	}
+	t := b.streamedTrack
+	if t == nil || t.data.Once {
+		return
+	}
+	// The track ended, SDL_mixer can only loop it from the beginning by itself
+	if err := t.music.FadeInPos(1, 0, t.data.LoopStart); err != nil {
+		log.Printf("could not loop music %v from %v seconds, looping from the beginning: %v", t.data.File, t.data.LoopStart, err)
+		t.data.LoopStart = 0
+		t.music.Play(1)
+	}
+}
+
+// SetDucked lowers the music volume, e.g. while the pause menu is open
+func (b *mixerBackend) SetDucked(ducked bool) {
+	b.ducked = ducked
+	b.applyVolume()
+}
+
+// SetVolume sets volume of the music and sounds in steps from 0 to constants.VolumeSteps
+func (b *mixerBackend) SetVolume(music, sound int) {
+	b.musicVolume, b.soundVolume = music, sound
+	b.applyVolume()
+}
+
+func (b *mixerBackend) applyVolume() {
+	music := b.musicVolume * mix.MAX_VOLUME / constants.VolumeSteps
+	if b.ducked {
+		music = music * constants.MusicDuckPercent / 100
+	}
+	mix.VolumeMusic(music)
+	mix.Volume(constants.StingerChannel, music)
+	// The channels after the stinger one play the sounds
+	for channel := constants.StingerChannel + 1; channel < constants.AudioChannels; channel++ {
+		mix.Volume(channel, b.soundVolume*mix.MAX_VOLUME/constants.VolumeSteps)
+	}
+}
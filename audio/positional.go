@@ -0,0 +1,73 @@
+package audio
+
+import (
+	"math"
+	"simpleplatformer/constants"
+
+	"github.com/veandco/go-sdl2/mix"
+)
+
+// positionSound returns stereo volumes and distance (0 is the closest) of a sound emitted at dx, dy from the listener.
+// Sounds from beyond the hearing distance are not audible.
+func positionSound(dx, dy int32) (left, right, distance uint8, audible bool) {
+	d := math.Hypot(float64(dx), float64(dy))
+	hearing := float64(constants.HearingDistance)
+	if d > hearing {
+		return 0, 0, 0, false
+	}
+	// -1 is fully to the left, 1 fully to the right
+	pan := math.Max(-1, math.Min(1, float64(dx)/float64(constants.PanningDistance)))
+	// Constant power panning scaled up, so that a sound in the middle is as loud as a non-positional one
+	angle := (pan + 1) * math.Pi / 4
+	left = uint8(255 * math.Min(1, math.Sqrt2*math.Cos(angle)))
+	right = uint8(255 * math.Min(1, math.Sqrt2*math.Sin(angle)))
+	return left, right, uint8(d * 255 / hearing), true
+}
+
+// Emitter is something making positional sounds, e.g. a character
+type Emitter interface {
+	Position() (x, y int32)
+}
+
+// SetListener moves the point the positional sounds are heard from
+func (b *mixerBackend) SetListener(x, y int32) {
+	b.listenerX, b.listenerY = x, y
+}
+
+// PlaySoundAt plays the sound made by the emitter, panned to its side and quieter with the distance from the listener
+func (b *mixerBackend) PlaySoundAt(s Sound, e Emitter) {
+	x, y := e.Position()
+	if _, _, _, audible := positionSound(x-b.listenerX, y-b.listenerY); !audible {
+		return
+	}
+	channel, err := b.chunks[s].Play(-1, 0)
+	if err != nil {
+		return
+	}
+	b.emitters[channel] = e
+	b.positionChannel(channel, e)
+}
+
+// positionChannel pans the channel to the emitter, the sound is stopped once the emitter goes out of hearing
+func (b *mixerBackend) positionChannel(channel int, e Emitter) {
+	x, y := e.Position()
+	left, right, distance, audible := positionSound(x-b.listenerX, y-b.listenerY)
+	if !audible {
+		mix.HaltChannel(channel)
+		delete(b.emitters, channel)
+		return
+	}
+	mix.SetPanning(channel, left, right)
+	mix.SetDistance(channel, distance)
+}
+
+// updateEmitters follows the emitters of the positional sounds still playing
+func (b *mixerBackend) updateEmitters() {
+	for channel, e := range b.emitters {
+		if mix.Playing(channel) == 0 {
+			delete(b.emitters, channel)
+			continue
+		}
+		b.positionChannel(channel, e)
+	}
+}
@@ -0,0 +1,43 @@
+package audio
+
+import (
+	"simpleplatformer/constants"
+	"testing"
+)
+
+func TestPositionSoundSides(t *testing.T) {
+	left, right, _, audible := positionSound(-constants.PanningDistance/2, 0)
+	if !audible || left <= right {
+		t.Errorf("sound on the left: got left %v, right %v, audible %v", left, right, audible)
+	}
+	left, right, _, audible = positionSound(constants.PanningDistance/2, 0)
+	if !audible || right <= left {
+		t.Errorf("sound on the right: got left %v, right %v, audible %v", left, right, audible)
+	}
+	left, right, _, _ = positionSound(-constants.PanningDistance, 0)
+	if right != 0 {
+		t.Errorf("sound fully on the left: got right %v, want 0", right)
+	}
+}
+
+func TestPositionSoundCenter(t *testing.T) {
+	// A sound at the listener is as loud as a non-positional one, see PlaySound
+	left, right, distance, audible := positionSound(0, 0)
+	if left != 255 || right != 255 || distance != 0 || !audible {
+		t.Errorf("got left %v, right %v, distance %v, audible %v, want 255, 255, 0, true", left, right, distance, audible)
+	}
+}
+
+func TestPositionSoundDistance(t *testing.T) {
+	_, _, near, _ := positionSound(0, constants.HearingDistance/4)
+	_, _, far, audible := positionSound(0, constants.HearingDistance)
+	if !audible || far <= near {
+		t.Errorf("got distance %v near and %v far, audible %v", near, far, audible)
+	}
+	if _, _, _, audible := positionSound(0, constants.HearingDistance+1); audible {
+		t.Error("sound beyond the hearing distance is audible")
+	}
+	if _, _, _, audible := positionSound(-constants.HearingDistance, -constants.HearingDistance); audible {
+		t.Error("sound beyond the hearing distance diagonally is audible")
+	}
+}
@@ -6,6 +6,11 @@ const (
 	Start GeneralState = iota
 	Play
 	Over
+	LevelComplete
+	CampaignComplete
+	NameEntry
+	Leaderboard
+	Paused
 )
 
 type RelativeRectPosition struct{ XIndex, YIndex int }
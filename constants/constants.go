@@ -18,17 +18,163 @@ const (
 	CharacterSightLimit = 8 * CharacterDestWidth
 	ScreenMarginHeight  = 5 * TileDestHeight
 	AiCooldownTime      = 350
+	AiPatrolDistance    = 3 * TileDestWidth
+	AiMaxAttackers      = 1
+	AiMaxFlankers       = 1
+	AiWaitingDistance   = 2 * CharacterDestWidth
+	AiEarshotDistance   = 10 * TileDestWidth
+	ArrowVX             = float32(2.5)
+	ArrowGravity        = float32(Gravity)
+	ArrowLifetime       = 400
+	ArcherMinDistance   = 4 * TileDestWidth
+	ArcherMaxDistance   = 7 * TileDestWidth
+	ArcherShootInterval = 150
+	BatPatrolAmplitude  = 20.0
+	BatPatrolFrequency  = 0.05
+	BatSwoopSpeed       = 3.0
+	BatSwoopTime        = 120
+	BatSwoopCooldown    = 200
+	BatSightLimit       = 3 * TileDestWidth
+	DefaultBossHealth   = 12
+	BossScale           = 2
+	BossAttackRange     = CharacterDestWidth
+	BossPhaseChangeTime = 100
+	BossIntroLength     = 200
+	BossOutroLength     = 250
+	BossHealthBarWidth  = 400
+	BossHealthBarHeight = 12
+	ArenaTriggerMargin  = 2 * TileDestWidth
+	CrumbleDelay        = 60
+	CrumbleRespawnTime  = 300
+	BounceImpulse       = float32(5.0)
+	IceGrip             = float32(0.03)
+	IceStopVX           = float32(0.1)
+	SlopeStickDistance  = int32(8)
+	SpikesKnockbackVX   = float32(2)
+	WaterGravity        = Gravity / 4
+	WaterVYMax          = 1.0
+	SwimVXFactor        = float32(0.6)
+	SwimStrokeVY        = 1.5
+	SwimStrokeInterval  = 20
+	BreathMax           = 600
+	BreathRefillRate    = 5
+	DrownDamageInterval = 60
+	BreathBarWidth      = 40
+	BreathBarHeight     = 5
+	CoinScore           = 10
+	GemScore            = 50
+	SlasherScore        = 100
+	SnakeScore          = 50
+	ArcherScore         = 150
+	BatScore            = 75
+	BossScore           = 1000
+	ComboTime           = 180
+	MaxComboMultiplier  = 5
+	ScoreEventLength    = 60
+	PlayerLives         = 3
+	StaminaBoostLength  = 600
+	PickupMagnetSpeed   = float32(3)
+	PickupMagnetRange   = float32(3 * TileDestWidth)
+	PickupBobFrequency  = 0.1
+	PickupBobAmplitude  = 3.0
+	LadderStepInterval  = 20
+	LadderGrabSpeed     = int32(2)
+	FallGravity         = 0.06
+	JumpCutSpeed        = 1.5
+	CoyoteTime          = 6
+	JumpBufferTime      = 8
+	// Damage amounts and knockback of the attacks and hazards
+	SwooshDamage      = 1
+	BossSwooshDamage  = 2
+	ArrowDamage       = 1
+	BiteDamage        = 1
+	SpikesDamage      = 1
+	DrownDamage       = 1
+	SwooshKnockbackVX = float32(CharacterVX)
+	ArrowKnockbackVX  = float32(CharacterVX)
+	BiteKnockbackVX   = float32(1.5)
+	// Frames for which a character cannot be hurt after recovering from a hit
+	PlayerInvulnerable = 120
+	EnemyInvulnerable  = 0
+	BossInvulnerable   = 30
+	// InvulnerableBlink is the number of frames the sprite is shown or hidden for while invulnerable
+	InvulnerableBlink = 4
+	// Player moveset, lengths and windows are given in frames
+	ComboLength              = 3
+	ComboHitLength           = 24
+	ComboLungeVX             = float32(1.5)
+	ComboLungeFriction       = float32(0.9)
+	ComboFinisherDamage      = 2
+	ComboFinisherKnockbackVX = float32(2.5)
+	AirSlashLength           = 30
+	PogoVY                   = 5
+	PogoBounceVY             = 3.5
+	ParryWindow              = 12
+	ParryKnockbackVX         = float32(2)
+	BlockPushbackFriction    = float32(0.8)
+	ChargeTime               = 60
+	HeavyDamage              = 3
+	HeavyKnockbackVX         = float32(3)
+	// Weapons, every attack uses up some of the CharacterStaminaMax stamina
+	SwordStaminaCost = 10
+	SpearStaminaCost = 15
+	SpearReach       = 12
+	SpearVX          = float32(3)
+	SpearLifetime    = 15
+	SpearDamage      = 2
+	SpearKnockbackVX = float32(2)
+	KnifeStaminaCost = 15
+	KnifeVX          = float32(4)
+	KnifeGravity     = float32(0.02)
+	KnifeLifetime    = 120
+	KnifeDamage      = 1
+	KnifeKnockbackVX = float32(0.5)
+	// Unlockable abilities, lengths and cooldowns are given in frames
+	DoubleJumpFactor = float32(0.85)
+	WallSlideVY      = float32(1)
+	WallJumpVX       = float32(2)
+	WallJumpLockTime = 12
+	DashVX           = float32(5)
+	DashTime         = 10
+	DashCooldown     = 45
 )
 
 const (
-	scaleX                = WindowWidth / 288
-	scaleY                = WindowHeight / 172
-	TileSourceWidth       = int32(16)
-	TileSourceHeight      = int32(128 / 8)
-	TileDestWidth         = int32(TileSourceWidth * scaleX)
-	TileDestHeight        = int32(TileSourceHeight * scaleY)
-	CharacterSourceWidth  = int32(32)
-	CharacterSourceHeight = int32(32)
-	CharacterDestWidth    = int32(CharacterSourceWidth * scaleX)
-	CharacterDestHeight   = int32(CharacterSourceHeight * scaleY)
+	scaleX                 = WindowWidth / 288
+	scaleY                 = WindowHeight / 172
+	TileSourceWidth        = int32(16)
+	TileSourceHeight       = int32(128 / 8)
+	TileDestWidth          = int32(TileSourceWidth * scaleX)
+	TileDestHeight         = int32(TileSourceHeight * scaleY)
+	CharacterSourceWidth   = int32(32)
+	CharacterSourceHeight  = int32(32)
+	CharacterDestWidth     = int32(CharacterSourceWidth * scaleX)
+	CharacterDestHeight    = int32(CharacterSourceHeight * scaleY)
+	ProjectileSourceWidth  = int32(16)
+	ProjectileSourceHeight = int32(16)
+	ProjectileDestWidth    = int32(ProjectileSourceWidth * scaleX)
+	ProjectileDestHeight   = int32(ProjectileSourceHeight * scaleY)
+)
+
+const (
+	CampaignPath = "assets/levels/campaign.json"
+	PhysicsPath  = "assets/physics.json"
+	SaveDirName  = "simpleplatformer"
+	SaveSlots    = 3
+	// HighScoreEntries is the number of entries kept in every high score table
+	HighScoreEntries = 8
+	NameLength       = 3
+	SoundsDir        = "assets/sounds"
+	MusicDir         = "assets/music"
+	AudioChannels    = 16
+	// StingerChannel is reserved for the short tracks played over the music
+	StingerChannel = 0
+	// MusicFadeTime is the time in milliseconds of fading a track in or out
+	MusicFadeTime    = 800
+	MusicDuckPercent = 30
+	VolumeSteps      = 10
+	// Sounds further away from the player than HearingDistance are not played
+	HearingDistance = 30 * TileDestWidth
+	// PanningDistance is how far to the side a sound must be to be heard from one speaker only
+	PanningDistance = 12 * TileDestWidth
 )
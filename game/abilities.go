@@ -0,0 +1,53 @@
+package game
+
+import (
+	"log"
+	"simpleplatformer/game/characters"
+	"simpleplatformer/game/pickups"
+	"simpleplatformer/save"
+)
+
+// abilityPickups are the abilities unlocked by the pickups
+var abilityPickups = map[pickups.Kind]characters.Ability{
+	pickups.DoubleJump: characters.DoubleJump,
+	pickups.WallJump:   characters.WallJump,
+	pickups.Dash:       characters.Dash,
+}
+
+// unlockedAbilities returns the abilities the player has unlocked in the previous levels
+func unlockedAbilities(p save.Progress) []characters.Ability {
+	abilities := []characters.Ability{}
+	for _, name := range p.Abilities {
+		a, err := characters.ParseAbility(name)
+		if err != nil {
+			log.Printf("could not load ability: %v", err)
+			continue
+		}
+		abilities = append(abilities, a)
+	}
+	return abilities
+}
+
+// unlockAbility lets the player use the ability from now on, also after losing a life
+func (g *Game) unlockAbility(a characters.Ability) {
+	if !g.player.HasAbility(a) {
+		g.abilities = append(g.abilities, a)
+	}
+	g.player.Unlock(a)
+}
+
+// unlockAbilities gives the unlocked abilities to the player, it's needed every time the player is created
+func (g *Game) unlockAbilities() {
+	for _, a := range g.abilities {
+		g.player.Unlock(a)
+	}
+}
+
+// abilityNames returns names of the unlocked abilities, as they are kept in the progress
+func (g *Game) abilityNames() []string {
+	names := []string{}
+	for _, a := range g.abilities {
+		names = append(names, a.String())
+	}
+	return names
+}
@@ -0,0 +1,225 @@
+package game
+
+import (
+	"simpleplatformer/constants"
+	"simpleplatformer/game/characters"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
+)
+
+type archerPatrollingStateMoveRight struct {
+	ctrl *aiEnemyArcherController
+}
+
+func (s *archerPatrollingStateMoveRight) update(platforms []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
+	s.ctrl.alarmIfNoticedPlayer(playerCharacter)
+	ch := s.ctrl.character
+	s.ctrl.destinationX = s.ctrl.startX + constants.AiPatrolDistance
+	ch.Move(constants.CharacterVX)
+	if ch.X > s.ctrl.destinationX || ch.IsCloseToPlatformRightEdge(platforms) {
+		s.ctrl.setState(s.ctrl.patrollingStand)
+	}
+}
+
+func (s *archerPatrollingStateMoveRight) String() string {
+	return "patrollingStateMoveRight"
+}
+
+type archerPatrollingStateMoveLeft struct {
+	ctrl *aiEnemyArcherController
+}
+
+func (s *archerPatrollingStateMoveLeft) update(platforms []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
+	s.ctrl.alarmIfNoticedPlayer(playerCharacter)
+	ch := s.ctrl.character
+	s.ctrl.destinationX = s.ctrl.startX - constants.AiPatrolDistance
+	ch.Move(-constants.CharacterVX)
+	if ch.X < s.ctrl.destinationX || ch.IsCloseToPlatformLeftEdge(platforms) {
+		s.ctrl.setState(s.ctrl.patrollingStand)
+	}
+}
+
+func (s *archerPatrollingStateMoveLeft) String() string {
+	return "patrollingStateMoveLeft"
+}
+
+type archerPatrollingStateStand struct {
+	ctrl *aiEnemyArcherController
+}
+
+func (s *archerPatrollingStateStand) update(_ []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
+	s.ctrl.alarmIfNoticedPlayer(playerCharacter)
+	s.ctrl.time++
+	s.ctrl.destinationX = s.ctrl.character.X
+	s.ctrl.character.Move(0)
+	if s.ctrl.time > 100 {
+		s.ctrl.time = 0
+		if s.ctrl.character.IsFacedRight() {
+			s.ctrl.setState(s.ctrl.patrollingMoveLeft)
+		} else {
+			s.ctrl.setState(s.ctrl.patrollingMoveRight)
+		}
+	}
+}
+
+func (s *archerPatrollingStateStand) String() string {
+	return "patrollingStateStand"
+}
+
+type archerAlarmedState struct {
+	ctrl *aiEnemyArcherController
+}
+
+func (s *archerAlarmedState) update(_ []*platforms.Platform, _ *characters.Character, _ []*characters.Character) {
+	s.ctrl.destinationX = s.ctrl.character.X
+	s.ctrl.character.Move(0)
+	s.ctrl.character.ShowAlarm()
+	// If finished showing alarm
+	if !s.ctrl.character.FinishedShowingAlarm() {
+		s.ctrl.time = 0
+		s.ctrl.setState(s.ctrl.shooting)
+	}
+}
+
+func (s *archerAlarmedState) String() string {
+	return "alarmedState"
+}
+
+// archerShootingState keeps the archer at a distance from the player and makes him shoot
+type archerShootingState struct {
+	ctrl *aiEnemyArcherController
+}
+
+func (s *archerShootingState) update(platforms []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
+	c := s.ctrl.character
+	s.ctrl.time++
+	s.ctrl.destinationX = c.X
+	if c.IsClimbing() {
+		climbLadderTowardsPlayer(c, playerCharacter, platforms, s.ctrl.ladders)
+		return
+	}
+	if !c.CharacterClose(playerCharacter) {
+		if climbLadderTowardsPlayer(c, playerCharacter, platforms, s.ctrl.ladders) {
+			return
+		}
+		c.Move(0)
+		s.ctrl.cooldownTime--
+		if s.ctrl.cooldownTime <= 0 {
+			s.ctrl.setState(s.ctrl.patrollingMoveLeft)
+		}
+		return
+	}
+	s.ctrl.cooldownTime = constants.AiCooldownTime
+	distance := playerCharacter.X - c.X
+	if distance < 0 {
+		distance = -distance
+	}
+	awayFromPlayer := float32(constants.CharacterVX)
+	if playerCharacter.X > c.X {
+		awayFromPlayer = -constants.CharacterVX
+	}
+	switch {
+	case distance < constants.ArcherMinDistance && !isCloseToPlatformEdge(c, platforms, awayFromPlayer):
+		s.ctrl.destinationX = c.X + int32(awayFromPlayer)*(constants.ArcherMinDistance-distance)
+		c.Move(awayFromPlayer)
+	case distance > constants.ArcherMaxDistance && !isCloseToPlatformEdge(c, platforms, -awayFromPlayer):
+		s.ctrl.destinationX = c.X - int32(awayFromPlayer)*(distance-constants.ArcherMaxDistance)
+		c.Move(-awayFromPlayer)
+	default:
+		c.Move(0)
+		if s.ctrl.time > constants.ArcherShootInterval {
+			s.ctrl.time = 0
+			c.AimAt(playerCharacter.X, playerCharacter.Y)
+			c.Attack()
+		}
+	}
+}
+
+func (s *archerShootingState) String() string {
+	return "shootingState"
+}
+
+func isCloseToPlatformEdge(c *characters.Character, platforms []*platforms.Platform, direction float32) bool {
+	if direction > 0 {
+		return c.IsCloseToPlatformRightEdge(platforms)
+	}
+	return c.IsCloseToPlatformLeftEdge(platforms)
+}
+
+func newAiEnemyArcherController(ch *characters.Character, coordinator *encounterCoordinator) aiEnemyController {
+	ctrl := &aiEnemyArcherController{
+		character:   ch,
+		coordinator: coordinator,
+		startX:      ch.X,
+		time:        0,
+	}
+	ctrl.patrollingStand = &archerPatrollingStateStand{ctrl}
+	ctrl.patrollingMoveRight = &archerPatrollingStateMoveRight{ctrl}
+	ctrl.patrollingMoveLeft = &archerPatrollingStateMoveLeft{ctrl}
+	ctrl.alarmed = &archerAlarmedState{ctrl}
+	ctrl.shooting = &archerShootingState{ctrl}
+	ctrl.setState(ctrl.patrollingMoveRight)
+	return ctrl
+}
+
+type aiEnemyArcherController struct {
+	character    *characters.Character
+	coordinator  *encounterCoordinator
+	startX       int32
+	destinationX int32
+	time         int
+	cooldownTime int
+	// ladders are the ones the enemy can climb this frame to get to the player height
+	ladders []*ladders.Ladder
+
+	currentPatrollingState patrollingStateInterface
+	patrollingStand        patrollingStateInterface
+	patrollingMoveRight    patrollingStateInterface
+	patrollingMoveLeft     patrollingStateInterface
+	alarmed                patrollingStateInterface
+	shooting               patrollingStateInterface
+}
+
+func (ai *aiEnemyArcherController) alarmIfNoticedPlayer(playerCharacter *characters.Character) {
+	if ai.character.CharacterWithinSight(playerCharacter) {
+		ai.setState(ai.alarmed)
+		ai.coordinator.raiseAlarm(ai)
+	}
+}
+
+func (ai *aiEnemyArcherController) setState(state patrollingStateInterface) {
+	ai.currentPatrollingState = state
+}
+
+func (ai *aiEnemyArcherController) update(platforms []*platforms.Platform, ladders []*ladders.Ladder, playerCharacter *characters.Character, enemies []*characters.Character) {
+	ai.ladders = ladders
+	ai.currentPatrollingState.update(platforms, playerCharacter, enemies)
+}
+
+func (ai *aiEnemyArcherController) shiftPatrollingReferencePoint(dx, _ int32) {
+	ai.startX += dx
+}
+
+func (ai *aiEnemyArcherController) alert() {
+	if ai.character.IsDead() {
+		return
+	}
+	switch ai.currentPatrollingState {
+	case ai.patrollingStand, ai.patrollingMoveLeft, ai.patrollingMoveRight:
+		ai.setState(ai.alarmed)
+	}
+}
+
+func (ai *aiEnemyArcherController) getCharacter() *characters.Character {
+	return ai.character
+}
+
+func (ai *aiEnemyArcherController) debugInfo() aiDebugInfo {
+	return aiDebugInfo{
+		character:    ai.character,
+		state:        ai.currentPatrollingState.String(),
+		startX:       ai.startX,
+		destinationX: ai.destinationX,
+		cooldownTime: ai.cooldownTime,
+	}
+}
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"math"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/characters"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
+)
+
+type batPatrollingState struct {
+	ctrl *aiEnemyBatController
+}
+
+func (s *batPatrollingState) update(_ []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
+	ctrl := s.ctrl
+	c := ctrl.character
+	ctrl.time++
+	if ctrl.cooldownTime > 0 {
+		ctrl.cooldownTime--
+	}
+	if c.X > ctrl.startX+constants.AiPatrolDistance {
+		ctrl.direction = -1
+	} else if c.X < ctrl.startX-constants.AiPatrolDistance {
+		ctrl.direction = 1
+	}
+	ctrl.destinationX = ctrl.startX + int32(ctrl.direction)*constants.AiPatrolDistance
+	// Bat follows the sine wave around its base height
+	targetY := ctrl.baseY + int32(constants.BatPatrolAmplitude*math.Sin(float64(ctrl.time)*constants.BatPatrolFrequency))
+	c.Fly(ctrl.direction*constants.CharacterVX, float32(targetY-c.Y))
+	if ctrl.cooldownTime == 0 && ctrl.noticedPlayer(playerCharacter) {
+		ctrl.targetX, ctrl.targetY = playerCharacter.X, playerCharacter.Y
+		ctrl.time = 0
+		ctrl.setState(ctrl.swooping)
+	}
+}
+
+func (s *batPatrollingState) String() string {
+	return "patrollingState"
+}
+
+// batSwoopingState makes the bat dive at the place where it noticed the player
+type batSwoopingState struct {
+	ctrl *aiEnemyBatController
+}
+
+func (s *batSwoopingState) update(_ []*platforms.Platform, _ *characters.Character, _ []*characters.Character) {
+	ctrl := s.ctrl
+	c := ctrl.character
+	ctrl.time++
+	ctrl.destinationX = ctrl.targetX
+	dx := float64(ctrl.targetX - c.X)
+	dy := float64(ctrl.targetY - c.Y)
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance < constants.BatSwoopSpeed || ctrl.time > constants.BatSwoopTime {
+		ctrl.setState(ctrl.returning)
+		return
+	}
+	c.Fly(float32(dx/distance*constants.BatSwoopSpeed), float32(dy/distance*constants.BatSwoopSpeed))
+}
+
+func (s *batSwoopingState) String() string {
+	return "swoopingState"
+}
+
+type batReturningState struct {
+	ctrl *aiEnemyBatController
+}
+
+func (s *batReturningState) update(_ []*platforms.Platform, _ *characters.Character, _ []*characters.Character) {
+	ctrl := s.ctrl
+	c := ctrl.character
+	ctrl.destinationX = c.X
+	if c.Y <= ctrl.baseY {
+		ctrl.time = 0
+		ctrl.cooldownTime = constants.BatSwoopCooldown
+		ctrl.setState(ctrl.patrolling)
+		return
+	}
+	c.Fly(0, -constants.BatSwoopSpeed/2)
+}
+
+func (s *batReturningState) String() string {
+	return "returningState"
+}
+
+func newAiEnemyBatController(ch *characters.Character) aiEnemyController {
+	ctrl := &aiEnemyBatController{
+		character: ch,
+		startX:    ch.X,
+		baseY:     ch.Y,
+		direction: 1,
+		time:      0,
+	}
+	ctrl.patrolling = &batPatrollingState{ctrl}
+	ctrl.swooping = &batSwoopingState{ctrl}
+	ctrl.returning = &batReturningState{ctrl}
+	ctrl.setState(ctrl.patrolling)
+	return ctrl
+}
+
+type aiEnemyBatController struct {
+	character    *characters.Character
+	startX       int32
+	baseY        int32
+	targetX      int32
+	targetY      int32
+	destinationX int32
+	direction    float32
+	time         int
+	cooldownTime int
+
+	currentPatrollingState patrollingStateInterface
+	patrolling             patrollingStateInterface
+	swooping               patrollingStateInterface
+	returning              patrollingStateInterface
+}
+
+// noticedPlayer returns true if the player is below the bat and close enough horizontally
+func (ai *aiEnemyBatController) noticedPlayer(playerCharacter *characters.Character) bool {
+	if playerCharacter.IsDead() || playerCharacter.Y < ai.character.Y {
+		return false
+	}
+	distance := playerCharacter.X - ai.character.X
+	return distance > -constants.BatSightLimit && distance < constants.BatSightLimit
+}
+
+func (ai *aiEnemyBatController) setState(state patrollingStateInterface) {
+	ai.currentPatrollingState = state
+}
+
+func (ai *aiEnemyBatController) update(platforms []*platforms.Platform, _ []*ladders.Ladder, playerCharacter *characters.Character, enemies []*characters.Character) {
+	if ai.character.IsDead() {
+		return
+	}
+	ai.currentPatrollingState.update(platforms, playerCharacter, enemies)
+}
+
+func (ai *aiEnemyBatController) shiftPatrollingReferencePoint(dx, dy int32) {
+	ai.startX += dx
+	ai.baseY += dy
+	ai.targetX += dx
+	ai.targetY += dy
+}
+
+func (ai *aiEnemyBatController) alert() {}
+
+func (ai *aiEnemyBatController) getCharacter() *characters.Character {
+	return ai.character
+}
+
+func (ai *aiEnemyBatController) debugInfo() aiDebugInfo {
+	return aiDebugInfo{
+		character:    ai.character,
+		state:        ai.currentPatrollingState.String(),
+		startX:       ai.startX,
+		destinationX: ai.destinationX,
+		cooldownTime: ai.cooldownTime,
+	}
+}
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"simpleplatformer/constants"
+	"simpleplatformer/game/characters"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
+)
+
+// bossPhase describes boss behaviour until its health drops to the threshold of the next phase
+type bossPhase struct {
+	healthThreshold int
+	speed           float32
+	attackInterval  int
+	jumpInterval    int // boss does not jump if 0
+}
+
+var bossPhases = []bossPhase{
+	{healthThreshold: constants.DefaultBossHealth, speed: constants.CharacterVX, attackInterval: 90, jumpInterval: 0},
+	{healthThreshold: constants.DefaultBossHealth * 2 / 3, speed: constants.CharacterVX, attackInterval: 60, jumpInterval: 300},
+	{healthThreshold: constants.DefaultBossHealth / 3, speed: 2 * constants.CharacterVX, attackInterval: 40, jumpInterval: 180},
+}
+
+type bossWaitingState struct {
+	ctrl *aiBossController
+}
+
+func (s *bossWaitingState) update(_ []*platforms.Platform, _ *characters.Character, _ []*characters.Character) {
+	s.ctrl.destinationX = s.ctrl.character.X
+	s.ctrl.character.Move(0)
+}
+
+func (s *bossWaitingState) String() string {
+	return "waitingState"
+}
+
+type bossFightingState struct {
+	ctrl *aiBossController
+}
+
+func (s *bossFightingState) update(_ []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
+	ctrl := s.ctrl
+	c := ctrl.character
+	if ctrl.phase+1 < len(bossPhases) && c.Health() <= bossPhases[ctrl.phase+1].healthThreshold {
+		ctrl.phase++
+		ctrl.time = 0
+		ctrl.setState(ctrl.changingPhase)
+		return
+	}
+	phase := bossPhases[ctrl.phase]
+	ctrl.time++
+	ctrl.jumpTime++
+	ctrl.destinationX = playerCharacter.X
+	distance := playerCharacter.X - c.X
+	switch {
+	case distance > constants.BossAttackRange:
+		c.Move(phase.speed)
+	case distance < -constants.BossAttackRange:
+		c.Move(-phase.speed)
+	default:
+		c.Move(0)
+		c.AimAt(playerCharacter.X, playerCharacter.Y)
+		if ctrl.time > phase.attackInterval {
+			ctrl.time = 0
+			c.Attack()
+		}
+	}
+	if phase.jumpInterval > 0 && ctrl.jumpTime > phase.jumpInterval {
+		ctrl.jumpTime = 0
+		c.Jump()
+	}
+}
+
+func (s *bossFightingState) String() string {
+	return "fightingState"
+}
+
+// bossChangingPhaseState makes the boss roar for a while before it starts the next phase
+type bossChangingPhaseState struct {
+	ctrl *aiBossController
+}
+
+func (s *bossChangingPhaseState) update(_ []*platforms.Platform, _ *characters.Character, _ []*characters.Character) {
+	ctrl := s.ctrl
+	ctrl.destinationX = ctrl.character.X
+	if ctrl.time == 0 {
+		ctrl.character.Move(0)
+		ctrl.character.ShowAlarm()
+	}
+	ctrl.time++
+	if ctrl.time > constants.BossPhaseChangeTime {
+		ctrl.time = 0
+		ctrl.jumpTime = 0
+		ctrl.setState(ctrl.fighting)
+	}
+}
+
+func (s *bossChangingPhaseState) String() string {
+	return "changingPhaseState"
+}
+
+func newAiBossController(ch *characters.Character) *aiBossController {
+	ctrl := &aiBossController{
+		character: ch,
+		time:      0,
+	}
+	ctrl.waiting = &bossWaitingState{ctrl}
+	ctrl.fighting = &bossFightingState{ctrl}
+	ctrl.changingPhase = &bossChangingPhaseState{ctrl}
+	ctrl.setState(ctrl.waiting)
+	return ctrl
+}
+
+type aiBossController struct {
+	character    *characters.Character
+	destinationX int32
+	phase        int
+	time         int
+	jumpTime     int
+
+	currentPatrollingState patrollingStateInterface
+	waiting                patrollingStateInterface
+	fighting               patrollingStateInterface
+	changingPhase          patrollingStateInterface
+}
+
+// start makes the boss attack the player
+func (ai *aiBossController) start() {
+	ai.time = 0
+	ai.setState(ai.fighting)
+}
+
+func (ai *aiBossController) setState(state patrollingStateInterface) {
+	ai.currentPatrollingState = state
+}
+
+func (ai *aiBossController) update(platforms []*platforms.Platform, _ []*ladders.Ladder, playerCharacter *characters.Character, enemies []*characters.Character) {
+	if ai.character.IsDead() {
+		return
+	}
+	ai.currentPatrollingState.update(platforms, playerCharacter, enemies)
+}
+
+func (ai *aiBossController) shiftPatrollingReferencePoint(_, _ int32) {}
+
+func (ai *aiBossController) alert() {}
+
+func (ai *aiBossController) getCharacter() *characters.Character {
+	return ai.character
+}
+
+func (ai *aiBossController) debugInfo() aiDebugInfo {
+	return aiDebugInfo{
+		character:    ai.character,
+		state:        ai.currentPatrollingState.String(),
+		startX:       ai.character.X,
+		destinationX: ai.destinationX,
+		cooldownTime: ai.time,
+	}
+}
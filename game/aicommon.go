@@ -1,12 +1,103 @@
 package game
 
 import (
+	"simpleplatformer/constants"
 	"simpleplatformer/game/characters"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
 )
 
 func showAlarmIfNoticedPlayer(ctrl *aiEnemySlasherController, playerCharacter *characters.Character) {
 	c := ctrl.character
 	if c.CharacterWithinSight(playerCharacter) {
 		ctrl.setState(ctrl.alarmed)
+		ctrl.coordinator.raiseAlarm(ctrl)
 	}
 }
+
+// dropDownIfPlayerBelow makes the character follow the player who is below, returns true if the character dropped down
+func dropDownIfPlayerBelow(c *characters.Character, playerCharacter *characters.Character, platforms []*platforms.Platform) bool {
+	distance := playerCharacter.X - c.X
+	if distance < 0 {
+		distance = -distance
+	}
+	if playerCharacter.Y <= c.Y+constants.TileDestHeight || distance > constants.CharacterSightLimit || !c.CanDropDown(platforms) {
+		return false
+	}
+	c.Move(0)
+	c.DropDown(platforms)
+	return true
+}
+
+// climbLadderTowardsPlayer makes the character follow the player who is above or below it by climbing
+// the nearest ladder, it jumps off to the player's side once at their height or at the top of a rope or vine.
+// Returns true if it's on its way.
+func climbLadderTowardsPlayer(c *characters.Character, playerCharacter *characters.Character, platforms []*platforms.Platform, lads []*ladders.Ladder) bool {
+	dy := playerCharacter.Y - c.Y
+	towardsPlayer := float32(constants.CharacterVX)
+	if playerCharacter.X < c.X {
+		towardsPlayer = -towardsPlayer
+	}
+	if c.IsClimbing() {
+		switch {
+		case dy < -constants.TileDestHeight/2 && !c.IsAtRopeTop():
+			c.Climb(-constants.CharacterVY, lads)
+		case dy > constants.TileDestHeight/2:
+			c.Climb(constants.CharacterVY, lads)
+		default:
+			c.Move(towardsPlayer)
+			c.Jump()
+		}
+		return true
+	}
+	if dy >= -constants.TileDestHeight && dy <= constants.TileDestHeight {
+		return false
+	}
+	l := nearestLadder(c, lads, dy < 0)
+	if l == nil {
+		return false
+	}
+	dx := l.X - c.X
+	if dx > l.W/4 || dx < -l.W/4 {
+		direction := float32(constants.CharacterVX)
+		if dx < 0 {
+			direction = -direction
+		}
+		if isCloseToPlatformEdge(c, platforms, direction) {
+			return false
+		}
+		c.Move(direction)
+		return true
+	}
+	c.Move(0)
+	if dy < 0 {
+		c.Climb(-constants.CharacterVY, lads)
+	} else {
+		c.Climb(constants.CharacterVY, lads)
+	}
+	return true
+}
+
+// nearestLadder returns the closest ladder within sight which goes up or down from where the character stands
+func nearestLadder(c *characters.Character, lads []*ladders.Ladder, up bool) *ladders.Ladder {
+	var nearest *ladders.Ladder
+	nearestDistance := constants.CharacterSightLimit
+	feet := c.Y + c.H
+	for _, l := range lads {
+		top, bottom := l.Y-l.H/2, l.Y+l.H/2
+		if up && (feet <= top || feet > bottom) {
+			continue
+		}
+		if !up && (feet < top || feet >= bottom) {
+			continue
+		}
+		distance := l.X - c.X
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < nearestDistance {
+			nearest, nearestDistance = l, distance
+		}
+	}
+	return nearest
+}
@@ -2,9 +2,9 @@ package game
 
 import (
 	"errors"
-	"log"
 	"simpleplatformer/constants"
 	"simpleplatformer/game/characters"
+	"simpleplatformer/game/ladders"
 	"simpleplatformer/game/platforms"
 )
 
@@ -15,9 +15,11 @@ type patrollingStateInterface interface {
 
 type aiEnemyController interface {
 	setState(state patrollingStateInterface)
-	update([]*platforms.Platform, *characters.Character, []*characters.Character)
-	shiftPatrollingReferencePointRight()
-	shiftPatrollingReferencePointLeft()
+	update([]*platforms.Platform, []*ladders.Ladder, *characters.Character, []*characters.Character)
+	shiftPatrollingReferencePoint(int32, int32)
+	alert()
+	getCharacter() *characters.Character
+	debugInfo() aiDebugInfo
 }
 
 type slasherPatrollingStateMoveRight struct {
@@ -27,8 +29,9 @@ type slasherPatrollingStateMoveRight struct {
 func (s *slasherPatrollingStateMoveRight) update(platforms []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
 	showAlarmIfNoticedPlayer(s.ctrl, playerCharacter)
 	ch := s.ctrl.character
+	s.ctrl.destinationX = s.ctrl.startX + constants.AiPatrolDistance
 	ch.Move(constants.CharacterVX)
-	if ch.X > s.ctrl.startX+(3*constants.TileDestWidth) || ch.IsCloseToPlatformRightEdge(platforms) {
+	if ch.X > s.ctrl.destinationX || ch.IsCloseToPlatformRightEdge(platforms) {
 		s.ctrl.setState(s.ctrl.patrollingStand)
 	}
 }
@@ -44,8 +47,9 @@ type slasherPatrollingStateMoveLeft struct {
 func (s *slasherPatrollingStateMoveLeft) update(platforms []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
 	showAlarmIfNoticedPlayer(s.ctrl, playerCharacter)
 	ch := s.ctrl.character
+	s.ctrl.destinationX = s.ctrl.startX - constants.AiPatrolDistance
 	ch.Move(-constants.CharacterVX)
-	if ch.X < s.ctrl.startX-(3*constants.TileDestWidth) || ch.IsCloseToPlatformLeftEdge(platforms) {
+	if ch.X < s.ctrl.destinationX || ch.IsCloseToPlatformLeftEdge(platforms) {
 		s.ctrl.setState(s.ctrl.patrollingStand)
 	}
 }
@@ -61,6 +65,7 @@ type slasherPatrollingStateStand struct {
 func (s *slasherPatrollingStateStand) update(_ []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
 	showAlarmIfNoticedPlayer(s.ctrl, playerCharacter)
 	s.ctrl.time++
+	s.ctrl.destinationX = s.ctrl.character.X
 	s.ctrl.character.Move(0)
 	if s.ctrl.time > 100 {
 		s.ctrl.time = 0
@@ -81,6 +86,7 @@ type slasherAlarmedState struct {
 }
 
 func (s *slasherAlarmedState) update(_ []*platforms.Platform, _ *characters.Character, _ []*characters.Character) {
+	s.ctrl.destinationX = s.ctrl.character.X
 	s.ctrl.character.Move(0)
 	s.ctrl.character.ShowAlarm()
 	// If finished showing alarm
@@ -97,32 +103,42 @@ type slasherChasingState struct {
 	ctrl *aiEnemySlasherController
 }
 
-func (s *slasherChasingState) update(platforms []*platforms.Platform, playerCharacter *characters.Character, enemies []*characters.Character) {
+func (s *slasherChasingState) update(platforms []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
 	c := s.ctrl.character
-	otherEnemyWithinAttackRange := false
-	for _, e := range enemies {
-		if e == c {
-			continue
-		}
-		if c.CharacterWithinSight(e) && c.CharacterWithinAttackRange(e) {
-			otherEnemyWithinAttackRange = true
-			break
-		}
+	coordinator := s.ctrl.coordinator
+	s.ctrl.destinationX = c.X
+	if c.IsClimbing() {
+		climbLadderTowardsPlayer(c, playerCharacter, platforms, s.ctrl.ladders)
+		return
 	}
 	if c.CharacterClose(playerCharacter) {
 		s.ctrl.cooldownTime = constants.AiCooldownTime
-		if c.CharacterWithinAttackRange(playerCharacter) {
-			c.Attack()
+		tolerance := constants.CharacterDestWidth / 2
+		if coordinator.requestAttackToken(s.ctrl) {
+			s.ctrl.destinationX = playerCharacter.X
+			if c.CharacterWithinAttackRange(playerCharacter) {
+				c.Attack()
+			}
+		} else {
+			s.ctrl.destinationX = coordinator.waitingPosition(s.ctrl, playerCharacter)
+			tolerance = constants.CharacterVX
 		}
-		if playerCharacter.X-constants.CharacterDestWidth/2 > c.X && !otherEnemyWithinAttackRange && !c.IsCloseToPlatformRightEdge(platforms) {
+		if s.ctrl.destinationX-tolerance > c.X && !c.IsCloseToPlatformRightEdge(platforms) {
 			c.Move(constants.CharacterVX)
-		} else if playerCharacter.X+constants.CharacterDestWidth/2 < c.X && !otherEnemyWithinAttackRange && !c.IsCloseToPlatformLeftEdge(platforms) {
+		} else if s.ctrl.destinationX+tolerance < c.X && !c.IsCloseToPlatformLeftEdge(platforms) {
 			c.Move(-constants.CharacterVX)
 		} else {
 			c.Move(0)
 		}
 	} else {
-		if c.IsCloseToPlatformRightEdge(platforms) || c.IsCloseToPlatformLeftEdge(platforms) || otherEnemyWithinAttackRange {
+		coordinator.releaseTokens(s.ctrl)
+		if climbLadderTowardsPlayer(c, playerCharacter, platforms, s.ctrl.ladders) {
+			return
+		}
+		if dropDownIfPlayerBelow(c, playerCharacter, platforms) {
+			return
+		}
+		if c.IsCloseToPlatformRightEdge(platforms) || c.IsCloseToPlatformLeftEdge(platforms) {
 			c.Move(0)
 		}
 		s.ctrl.cooldownTime--
@@ -136,11 +152,12 @@ func (s *slasherChasingState) String() string {
 	return "chasingState"
 }
 
-func newAiEnemySlasherController(ch *characters.Character) aiEnemyController {
+func newAiEnemySlasherController(ch *characters.Character, coordinator *encounterCoordinator) aiEnemyController {
 	ctrl := &aiEnemySlasherController{
-		character: ch,
-		startX:    ch.X,
-		time:      0,
+		character:   ch,
+		coordinator: coordinator,
+		startX:      ch.X,
+		time:        0,
 	}
 	ctrl.patrollingStand = &slasherPatrollingStateStand{ctrl}
 	ctrl.patrollingMoveRight = &slasherPatrollingStateMoveRight{ctrl}
@@ -153,9 +170,13 @@ func newAiEnemySlasherController(ch *characters.Character) aiEnemyController {
 
 type aiEnemySlasherController struct {
 	character    *characters.Character
+	coordinator  *encounterCoordinator
 	startX       int32
+	destinationX int32
 	time         int
 	cooldownTime int
+	// ladders are the ones the enemy can climb this frame to follow the player
+	ladders []*ladders.Ladder
 
 	currentPatrollingState patrollingStateInterface
 	patrollingStand        patrollingStateInterface
@@ -166,20 +187,42 @@ type aiEnemySlasherController struct {
 }
 
 func (ai *aiEnemySlasherController) setState(state patrollingStateInterface) {
-	log.Println("switching to state:", state.String())
 	ai.currentPatrollingState = state
 }
 
-func (ai *aiEnemySlasherController) update(platforms []*platforms.Platform, playerCharacter *characters.Character, enemies []*characters.Character) {
+func (ai *aiEnemySlasherController) update(platforms []*platforms.Platform, ladders []*ladders.Ladder, playerCharacter *characters.Character, enemies []*characters.Character) {
+	ai.ladders = ladders
 	ai.currentPatrollingState.update(platforms, playerCharacter, enemies)
 }
 
-func (ai *aiEnemySlasherController) shiftPatrollingReferencePointRight() {
-	ai.startX++
+func (ai *aiEnemySlasherController) shiftPatrollingReferencePoint(dx, _ int32) {
+	ai.startX += dx
+}
+
+// alert makes the patrolling enemy notice the player, even if it cannot see him
+func (ai *aiEnemySlasherController) alert() {
+	if ai.character.IsDead() {
+		return
+	}
+	switch ai.currentPatrollingState {
+	case ai.patrollingStand, ai.patrollingMoveLeft, ai.patrollingMoveRight:
+		ai.setState(ai.alarmed)
+	}
+}
+
+func (ai *aiEnemySlasherController) getCharacter() *characters.Character {
+	return ai.character
 }
 
-func (ai *aiEnemySlasherController) shiftPatrollingReferencePointLeft() {
-	ai.startX--
+func (ai *aiEnemySlasherController) debugInfo() aiDebugInfo {
+	return aiDebugInfo{
+		character:    ai.character,
+		state:        ai.currentPatrollingState.String(),
+		role:         ai.coordinator.roleOf(ai),
+		startX:       ai.startX,
+		destinationX: ai.destinationX,
+		cooldownTime: ai.cooldownTime,
+	}
 }
 
 type snakePatrollingStateMoveRight struct {
@@ -188,8 +231,9 @@ type snakePatrollingStateMoveRight struct {
 
 func (s *snakePatrollingStateMoveRight) update(platforms []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
 	ch := s.ctrl.character
+	s.ctrl.destinationX = s.ctrl.startX + constants.AiPatrolDistance
 	ch.Move(constants.CharacterVX)
-	if ch.X > s.ctrl.startX+(3*constants.TileDestWidth) || ch.IsCloseToPlatformRightEdge(platforms) {
+	if ch.X > s.ctrl.destinationX || ch.IsCloseToPlatformRightEdge(platforms) {
 		s.ctrl.setState(s.ctrl.patrollingStand)
 	}
 }
@@ -204,8 +248,9 @@ type snakePatrollingStateMoveLeft struct {
 
 func (s *snakePatrollingStateMoveLeft) update(platforms []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
 	ch := s.ctrl.character
+	s.ctrl.destinationX = s.ctrl.startX - constants.AiPatrolDistance
 	ch.Move(-constants.CharacterVX)
-	if ch.X < s.ctrl.startX-(3*constants.TileDestWidth) || ch.IsCloseToPlatformLeftEdge(platforms) {
+	if ch.X < s.ctrl.destinationX || ch.IsCloseToPlatformLeftEdge(platforms) {
 		s.ctrl.setState(s.ctrl.patrollingStand)
 	}
 }
@@ -220,6 +265,7 @@ type snakePatrollingStateStand struct {
 
 func (s *snakePatrollingStateStand) update(_ []*platforms.Platform, playerCharacter *characters.Character, _ []*characters.Character) {
 	s.ctrl.time++
+	s.ctrl.destinationX = s.ctrl.character.X
 	s.ctrl.character.Move(0)
 	if s.ctrl.time > 100 {
 		s.ctrl.time = 0
@@ -251,6 +297,7 @@ func newAiEnemySnakeController(ch *characters.Character) aiEnemyController {
 type aiEnemySnakeController struct {
 	character    *characters.Character
 	startX       int32
+	destinationX int32
 	time         int
 	cooldownTime int
 
@@ -261,28 +308,43 @@ type aiEnemySnakeController struct {
 }
 
 func (ai *aiEnemySnakeController) setState(state patrollingStateInterface) {
-	log.Println("switching to state:", state.String())
 	ai.currentPatrollingState = state
 }
 
-func (ai *aiEnemySnakeController) update(platforms []*platforms.Platform, playerCharacter *characters.Character, enemies []*characters.Character) {
+func (ai *aiEnemySnakeController) update(platforms []*platforms.Platform, _ []*ladders.Ladder, playerCharacter *characters.Character, enemies []*characters.Character) {
 	ai.currentPatrollingState.update(platforms, playerCharacter, enemies)
 }
 
-func (ai *aiEnemySnakeController) shiftPatrollingReferencePointRight() {
-	ai.startX++
+func (ai *aiEnemySnakeController) shiftPatrollingReferencePoint(dx, _ int32) {
+	ai.startX += dx
 }
 
-func (ai *aiEnemySnakeController) shiftPatrollingReferencePointLeft() {
-	ai.startX--
+func (ai *aiEnemySnakeController) alert() {}
+
+func (ai *aiEnemySnakeController) getCharacter() *characters.Character {
+	return ai.character
+}
+
+func (ai *aiEnemySnakeController) debugInfo() aiDebugInfo {
+	return aiDebugInfo{
+		character:    ai.character,
+		state:        ai.currentPatrollingState.String(),
+		startX:       ai.startX,
+		destinationX: ai.destinationX,
+		cooldownTime: ai.cooldownTime,
+	}
 }
 
-func newAiControllerForEnemy(ch *characters.Character) (aiEnemyController, error) {
+func newAiControllerForEnemy(ch *characters.Character, coordinator *encounterCoordinator) (aiEnemyController, error) {
 	switch {
 	case ch.IsEnemySlasher():
-		return newAiEnemySlasherController(ch), nil
+		return newAiEnemySlasherController(ch, coordinator), nil
 	case ch.IsEnemySnake():
 		return newAiEnemySnakeController(ch), nil
+	case ch.IsEnemyArcher():
+		return newAiEnemyArcherController(ch, coordinator), nil
+	case ch.IsEnemyBat():
+		return newAiEnemyBatController(ch), nil
 	}
 	return nil, errors.New("unknown enemy type")
 }
@@ -0,0 +1,193 @@
+package game
+
+import (
+	"log"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/characters"
+
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// arena is the area where the boss fight takes place, it's closed until the boss is defeated
+type arena struct {
+	X int32
+	Y int32
+	W int32
+	H int32
+}
+
+func (a *arena) contains(c *characters.Character) bool {
+	return c.X > a.X-a.W/2+constants.ArenaTriggerMargin && c.X < a.X+a.W/2 && c.Y > a.Y-a.H/2 && c.Y < a.Y+a.H/2
+}
+
+// keepInside does not let the character walk through the closed gates
+func (a *arena) keepInside(c *characters.Character) {
+	left := a.X - a.W/2 + constants.TileDestWidth + c.W/2
+	right := a.X + a.W/2 - constants.TileDestWidth - c.W/2
+	if c.X < left {
+		c.X = left
+	}
+	if c.X > right {
+		c.X = right
+	}
+}
+
+type bossFightStage int
+
+const (
+	bossFightNotStarted bossFightStage = iota
+	bossFightIntro
+	bossFightInProgress
+	bossFightOutro
+	bossFightFinished
+)
+
+type bossFight struct {
+	name    string
+	arena   arena
+	ctrl    *aiBossController
+	stage   bossFightStage
+	time    int
+	texture *sdl.Texture
+	gate    *sdl.Rect
+}
+
+func newBossFight(name string, a arena, boss *characters.Character, texBackground *sdl.Texture) *bossFight {
+	return &bossFight{
+		name:    name,
+		arena:   a,
+		ctrl:    newAiBossController(boss),
+		stage:   bossFightNotStarted,
+		time:    0,
+		texture: texBackground,
+		gate:    &sdl.Rect{constants.TileSourceWidth * 8, constants.TileSourceHeight * 4, constants.TileSourceWidth, constants.TileSourceHeight},
+	}
+}
+
+// isLocked returns true if the camera should not follow the player and the arena exits are closed
+func (bf *bossFight) isLocked() bool {
+	return bf.stage == bossFightIntro || bf.stage == bossFightInProgress || bf.stage == bossFightOutro
+}
+
+// isScripted returns true if the player cannot be controlled
+func (bf *bossFight) isScripted() bool {
+	return bf.stage == bossFightIntro || bf.stage == bossFightOutro
+}
+
+func (bf *bossFight) isFinished() bool {
+	return bf.stage == bossFightFinished
+}
+
+// reset lets the boss fight start again after the player has respawned
+func (bf *bossFight) reset() {
+	if bf.stage == bossFightIntro || bf.stage == bossFightInProgress {
+		bf.stage = bossFightNotStarted
+		bf.time = 0
+		bf.ctrl.setState(bf.ctrl.waiting)
+	}
+}
+
+func (bf *bossFight) update(g *Game) {
+	boss := bf.ctrl.character
+	switch bf.stage {
+	case bossFightNotStarted:
+		if bf.arena.contains(g.player) {
+			bf.time = 0
+			bf.stage = bossFightIntro
+		}
+	case bossFightIntro:
+		g.player.Move(0)
+		// Pan the camera until the arena fits the screen
+		arenaScreenX := (constants.WindowWidth - bf.arena.W) / 2
+		if arenaLeft := bf.arena.X - bf.arena.W/2; arenaLeft != arenaScreenX {
+			dx := int32(-1)
+			if arenaLeft < arenaScreenX {
+				dx = 1
+			}
+			g.player.X += dx
+			g.shiftScreenX -= dx
+			g.shiftWorld(dx, 0)
+			return
+		}
+		if bf.time == 0 {
+			boss.ShowAlarm()
+		}
+		bf.time++
+		if bf.time > constants.BossIntroLength {
+			bf.time = 0
+			bf.stage = bossFightInProgress
+			bf.ctrl.start()
+		}
+	case bossFightInProgress:
+		bf.arena.keepInside(g.player)
+		bf.arena.keepInside(boss)
+		if boss.IsDead() {
+			bf.time = 0
+			bf.stage = bossFightOutro
+		}
+	case bossFightOutro:
+		g.player.Move(0)
+		bf.arena.keepInside(g.player)
+		bf.time++
+		if bf.time > constants.BossOutroLength {
+			bf.stage = bossFightFinished
+		}
+	}
+}
+
+func (bf *bossFight) shift(dx, dy int32) {
+	bf.arena.X += dx
+	bf.arena.Y += dy
+}
+
+func (bf *bossFight) draw(r *sdl.Renderer) {
+	if !bf.isLocked() {
+		return
+	}
+	for y := bf.arena.Y - bf.arena.H/2; y < bf.arena.Y+bf.arena.H/2; y += constants.TileDestHeight {
+		for _, x := range []int32{bf.arena.X - bf.arena.W/2, bf.arena.X + bf.arena.W/2 - constants.TileDestWidth} {
+			err := r.Copy(bf.texture, bf.gate, &sdl.Rect{x, y, constants.TileDestWidth, constants.TileDestHeight})
+			if err != nil {
+				log.Fatalf("could not copy arena gate texture: %v", err)
+			}
+		}
+	}
+
+	f := openFont(30)
+	defer f.Close()
+	textColor := sdl.Color{R: 255, G: 100, B: 0, A: 255}
+	var err error
+	switch bf.stage {
+	case bossFightIntro:
+		err = drawText(r, f, bf.name, constants.WindowWidth/2, constants.WindowHeight/4, textColor)
+	case bossFightInProgress:
+		err = bf.drawHealthBar(r, f)
+	case bossFightOutro:
+		err = drawText(r, f, bf.name+" defeated!", constants.WindowWidth/2, constants.WindowHeight/4, textColor)
+	}
+	if err != nil {
+		log.Fatalf("could not draw boss fight: %v", err)
+	}
+}
+
+func (bf *bossFight) drawHealthBar(r *sdl.Renderer, f *ttf.Font) error {
+	red, green, blue, alpha, err := r.GetDrawColor()
+	if err != nil {
+		return err
+	}
+	defer r.SetDrawColor(red, green, blue, alpha)
+
+	health := int32(bf.ctrl.character.Health())
+	if health < 0 {
+		health = 0
+	}
+	bar := sdl.Rect{(constants.WindowWidth - constants.BossHealthBarWidth) / 2, constants.TileDestHeight, constants.BossHealthBarWidth, constants.BossHealthBarHeight}
+	r.SetDrawColor(40, 0, 0, 255)
+	r.FillRect(&bar)
+	r.SetDrawColor(200, 30, 30, 255)
+	r.FillRect(&sdl.Rect{bar.X, bar.Y, bar.W * health / constants.DefaultBossHealth, bar.H})
+	r.SetDrawColor(255, 255, 255, 255)
+	r.DrawRect(&bar)
+	return drawText(r, f, bf.name, constants.WindowWidth/2, bar.Y+bar.H+4, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"simpleplatformer/audio"
+	"simpleplatformer/constants"
+	"simpleplatformer/save"
+)
+
+// campaignData lists paths of the level files in the order they are played
+type campaignData struct {
+	Levels []string `json:"levels"`
+}
+
+// Campaign leads the player through the levels one after another
+type Campaign struct {
+	levels   []string
+	Progress save.Progress
+}
+
+// NewCampaign loads the campaign manifest and starts it from the first level
+func NewCampaign(path string) *Campaign {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatalf("could not read campaign file: %v", err)
+	}
+	data := campaignData{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		log.Fatalf("could not parse campaign file %v: %v", path, err)
+	}
+	if len(data.Levels) == 0 {
+		log.Fatalf("campaign %v has no levels", path)
+	}
+	return &Campaign{
+		levels:   data.Levels,
+		Progress: save.Progress{Level: 0, Lives: constants.PlayerLives, Score: 0, Weapons: []string{"sword"}, Weapon: "sword"},
+	}
+}
+
+// StartLevel creates the game for the current level
+func (c *Campaign) StartLevel(textures Textures, sounds audio.Backend) *Game {
+	return NewGame(c.levels[c.Progress.Level], c.Progress, textures, sounds)
+}
+
+// CompleteLevel keeps the player progress from the finished game and moves on to the next level
+func (c *Campaign) CompleteLevel(g *Game) LevelStats {
+	c.Progress.Lives = g.lives
+	c.Progress.Score = g.score.points
+	c.Progress.Weapons = g.weaponNames()
+	c.Progress.Weapon = g.weapon.String()
+	c.Progress.Abilities = g.abilityNames()
+	c.Progress.Level++
+	return g.Stats()
+}
+
+// LevelCount returns the number of levels in the campaign
+func (c *Campaign) LevelCount() int {
+	return len(c.levels)
+}
+
+// IsFinished returns true if all the levels are completed
+func (c *Campaign) IsFinished() bool {
+	return c.Progress.Level >= len(c.levels)
+}
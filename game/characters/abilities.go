@@ -0,0 +1,340 @@
+package characters
+
+import (
+	"fmt"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Ability is a movement the player has to unlock before using it
+type Ability int
+
+const (
+	DoubleJump Ability = iota
+	WallJump
+	Dash
+)
+
+var abilityNames = []string{"doubleJump", "wallJump", "dash"}
+
+// ParseAbility returns the ability of the given name
+func ParseAbility(name string) (Ability, error) {
+	for i, n := range abilityNames {
+		if n == name {
+			return Ability(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown ability: %v", name)
+}
+
+// String returns the name of the ability, it's used in the save files
+func (a Ability) String() string {
+	return abilityNames[a]
+}
+
+// Unlock lets the character use the ability
+func (c *Character) Unlock(a Ability) {
+	c.abilities |= 1 << uint(a)
+}
+
+// HasAbility returns true if the character has unlocked the ability
+func (c *Character) HasAbility(a Ability) bool {
+	return c.abilities&(1<<uint(a)) != 0
+}
+
+// updateAbilities counts down the dash cooldown, the moves done in the air can be done again after landing
+func (c *Character) updateAbilities() {
+	if c.dashCooldown > 0 {
+		c.dashCooldown--
+	}
+	if c.ground != nil {
+		c.airJumped, c.airDashed = false, false
+	}
+}
+
+// doubleJumpingState is the second jump done in the air
+type doubleJumpingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+func (s *doubleJumpingState) move(newVX float32) {
+	setVelocityAndSwitchFacedRight(s.character, newVX)
+}
+
+func (s *doubleJumpingState) jump() {}
+
+func (s *doubleJumpingState) attack() {
+	conditionalSwitchToAirAttackingState(s.character)
+}
+
+func (s *doubleJumpingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *doubleJumpingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *doubleJumpingState) showAlarm() {}
+
+func (s *doubleJumpingState) climb(newVY float32, lads []*ladders.Ladder) {
+	conditionalClimbLadder(s.character, newVY, lads)
+}
+
+func (s *doubleJumpingState) fly(float32, float32) {}
+
+func (s *doubleJumpingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *doubleJumpingState) dropDown([]*platforms.Platform) {}
+
+func (s *doubleJumpingState) update([]*platforms.Platform, []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	c.applyGravity()
+	if c.isFalling() {
+		c.setState(c.falling)
+	}
+}
+
+func (s *doubleJumpingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *doubleJumpingState) String() string {
+	return "doubleJumpingState"
+}
+
+// conditionalDoubleJump jumps once more in the air, if the character can
+func conditionalDoubleJump(c *Character) {
+	if c.doubleJumping == nil || !c.HasAbility(DoubleJump) || c.airJumped {
+		return
+	}
+	c.airJumped = true
+	c.jumpBuffer = 0
+	c.vy = -physics.JumpSpeed * constants.DoubleJumpFactor
+	c.setState(c.doubleJumping)
+}
+
+// wallSlidingState slides slowly down the side of a solid platform the character pushes against
+type wallSlidingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+func (s *wallSlidingState) move(newVX float32) {
+	setVelocityAndSwitchFacedRight(s.character, newVX)
+}
+
+// jump pushes the character off the wall
+func (s *wallSlidingState) jump() {
+	c := s.character
+	c.vx = constants.WallJumpVX
+	if c.blockedRight {
+		c.vx = -c.vx
+	}
+	c.facedRight = c.vx > 0
+	c.vy = -physics.JumpSpeed
+	c.airJumped = false
+	c.jumpBuffer = 0
+	c.setState(c.wallJumping)
+}
+
+func (s *wallSlidingState) attack() {}
+
+func (s *wallSlidingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *wallSlidingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *wallSlidingState) showAlarm() {}
+
+func (s *wallSlidingState) climb(newVY float32, lads []*ladders.Ladder) {
+	conditionalClimbLadder(s.character, newVY, lads)
+}
+
+func (s *wallSlidingState) fly(float32, float32) {}
+
+func (s *wallSlidingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *wallSlidingState) dropDown([]*platforms.Platform) {}
+
+func (s *wallSlidingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	c.applyGravity()
+	if c.vy > constants.WallSlideVY {
+		c.vy = constants.WallSlideVY
+	}
+	if conditionalLand(c, platforms) {
+		return
+	}
+	if !c.isPushingAgainstWall() {
+		c.setState(c.falling)
+	}
+}
+
+func (s *wallSlidingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *wallSlidingState) String() string {
+	return "wallSlidingState"
+}
+
+func (c *Character) isPushingAgainstWall() bool {
+	return (c.blockedLeft && c.vx < 0) || (c.blockedRight && c.vx > 0)
+}
+
+// conditionalWallSlide starts sliding down the wall the falling character pushes against
+func conditionalWallSlide(c *Character) bool {
+	if c.wallSliding == nil || !c.HasAbility(WallJump) || !c.isFalling() || !c.isPushingAgainstWall() {
+		return false
+	}
+	c.setState(c.wallSliding)
+	return true
+}
+
+// wallJumpingState is the jump off a wall, the character cannot steer back to the wall for a moment
+type wallJumpingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+func (s *wallJumpingState) move(newVX float32) {
+	if s.character.time > constants.WallJumpLockTime {
+		setVelocityAndSwitchFacedRight(s.character, newVX)
+	}
+}
+
+func (s *wallJumpingState) jump() {
+	conditionalDoubleJump(s.character)
+}
+
+func (s *wallJumpingState) attack() {
+	conditionalSwitchToAirAttackingState(s.character)
+}
+
+func (s *wallJumpingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *wallJumpingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *wallJumpingState) showAlarm() {}
+
+func (s *wallJumpingState) climb(newVY float32, lads []*ladders.Ladder) {
+	conditionalClimbLadder(s.character, newVY, lads)
+}
+
+func (s *wallJumpingState) fly(float32, float32) {}
+
+func (s *wallJumpingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *wallJumpingState) dropDown([]*platforms.Platform) {}
+
+func (s *wallJumpingState) update([]*platforms.Platform, []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	c.applyGravity()
+	if c.isFalling() {
+		c.setState(c.falling)
+	}
+}
+
+func (s *wallJumpingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *wallJumpingState) String() string {
+	return "wallJumpingState"
+}
+
+// dashingState rushes horizontally for a moment, not pulled down by the gravity
+type dashingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+func (s *dashingState) move(float32) {}
+
+func (s *dashingState) jump() {}
+
+func (s *dashingState) attack() {}
+
+func (s *dashingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *dashingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *dashingState) showAlarm() {}
+
+func (s *dashingState) climb(float32, []*ladders.Ladder) {}
+
+func (s *dashingState) fly(float32, float32) {}
+
+func (s *dashingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *dashingState) dropDown([]*platforms.Platform) {}
+
+func (s *dashingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	c.vy = 0
+	if c.time <= constants.DashTime && !c.blockedLeft && !c.blockedRight {
+		return
+	}
+	c.resetVX()
+	if c.stickToGround(platforms) {
+		c.setState(c.standing)
+	} else {
+		c.setState(c.falling)
+	}
+}
+
+func (s *dashingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *dashingState) String() string {
+	return "dashingState"
+}
+
+// Dash rushes the character forward, in the air only once before landing
+func (c *Character) Dash() {
+	if c.dashing == nil || !c.HasAbility(Dash) || c.dashCooldown > 0 || c.airDashed {
+		return
+	}
+	switch c.currentState {
+	case c.standing, c.walking, c.jumping, c.falling, c.doubleJumping, c.wallJumping:
+	default:
+		return
+	}
+	c.airDashed = c.ground == nil
+	c.dashCooldown = constants.DashCooldown
+	c.vx = constants.DashVX
+	if !c.facedRight {
+		c.vx = -c.vx
+	}
+	c.vy = 0
+	c.setState(c.dashing)
+}
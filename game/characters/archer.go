@@ -0,0 +1,113 @@
+package characters
+
+import (
+	"simpleplatformer/common"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/platforms"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// NewArcher creates an enemy attacking with arrows flying in an arc
+func NewArcher(x, y int32, characterTexture *sdl.Texture, projectileTexture *sdl.Texture) *Character {
+	standingArcherRects := newCharacterAnimationRects([]common.RelativeRectPosition{{0, 2}})
+	walkingArcherRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{1, 2},
+		{2, 2},
+		{3, 2},
+		{4, 2},
+	})
+	jumpingUpwardArcherRects := newCharacterAnimationRects([]common.RelativeRectPosition{{6, 2}})
+	fallingArcherRects := newCharacterAnimationRects([]common.RelativeRectPosition{{7, 2}})
+	attackingArcherRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{12, 2},
+		{11, 2},
+		{12, 2},
+		{13, 2},
+	})
+	hitArcherRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{9, 2},
+		{10, 2},
+	})
+	climbingArcherRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{19, 2},
+		{20, 2},
+		{21, 2},
+		{22, 2},
+	})
+
+	c := Character{
+		X:                 x,
+		Y:                 y,
+		W:                 constants.TileDestWidth,
+		H:                 constants.TileDestHeight,
+		vx:                0,
+		vy:                0,
+		texture:           characterTexture,
+		swooshTexture:     nil,
+		projectileTexture: projectileTexture,
+		stamina:           constants.CharacterStaminaMax,
+		health:            constants.DefaultEnemyHealth,
+		time:              0,
+		facedRight:        true,
+		projectiles:       []*projectile{},
+		characterType:     enemyArcher,
+		faction:           enemyFaction,
+	}
+	c.newProjectile = newArrowForCharacter
+	c.updateAttack = func(platforms []*platforms.Platform, enemies []*Character) {
+		updateProjectileAttack(&c, platforms, enemies)
+	}
+	standingArcherState := standingState{
+		character:      &c,
+		animationRects: standingArcherRects,
+	}
+	walkingArcherState := walkingState{
+		character:      &c,
+		animationRects: walkingArcherRects,
+	}
+	jumpingArcherState := jumpingState{
+		character:      &c,
+		animationRects: jumpingUpwardArcherRects,
+	}
+	fallingArcherState := fallingState{
+		character:      &c,
+		animationRects: fallingArcherRects,
+	}
+	attackingArcherState := attackingState{
+		character:      &c,
+		animationRects: attackingArcherRects,
+	}
+	hitArcherState := hitState{
+		character:      &c,
+		animationRects: hitArcherRects,
+	}
+	climbingArcherState := climbingState{
+		character:      &c,
+		animationRects: climbingArcherRects,
+	}
+	swimmingArcherState := swimmingState{
+		character:      &c,
+		animationRects: walkingArcherRects,
+	}
+	deadArcherState := deadState{
+		character:      &c,
+		animationRects: hitArcherRects,
+	}
+	showingAlarmArcherState := showingAlarmState{
+		character:      &c,
+		animationRects: standingArcherRects,
+	}
+	c.standing = &standingArcherState
+	c.walking = &walkingArcherState
+	c.jumping = &jumpingArcherState
+	c.falling = &fallingArcherState
+	c.attacking = &attackingArcherState
+	c.hit = &hitArcherState
+	c.dead = &deadArcherState
+	c.swimming = &swimmingArcherState
+	c.climbing = &climbingArcherState
+	c.showingAlarm = &showingAlarmArcherState
+	c.setState(c.falling)
+	return &c
+}
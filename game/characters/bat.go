@@ -0,0 +1,129 @@
+package characters
+
+import (
+	"simpleplatformer/common"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// flyingState is not affected by gravity, velocity is fully controlled with fly
+type flyingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+func (s *flyingState) move(newVX float32) {
+	setVelocityAndSwitchFacedRight(s.character, newVX)
+}
+
+func (s *flyingState) jump() {}
+
+func (s *flyingState) attack() {}
+
+func (s *flyingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *flyingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *flyingState) showAlarm() {}
+
+func (s *flyingState) climb(float32, []*ladders.Ladder) {}
+
+func (s *flyingState) fly(newVX, newVY float32) {
+	setVelocityAndSwitchFacedRight(s.character, newVX)
+	s.character.vy = newVY
+}
+
+func (s *flyingState) swim() {}
+
+func (s *flyingState) dropDown([]*platforms.Platform) {}
+
+func (s *flyingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	for _, p := range platforms {
+		if !c.isInsidePlatform(p) {
+			continue
+		}
+		// Try to undo the horizontal move first, then the vertical one
+		vx, vy := c.vx, c.vy
+		c.X -= int32(vx)
+		c.vx = 0
+		if c.isInsidePlatform(p) {
+			c.X += int32(vx)
+			c.vx = vx
+			c.Y -= int32(vy)
+			c.vy = 0
+		}
+	}
+}
+
+func (s *flyingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *flyingState) String() string {
+	return "flyingState"
+}
+
+func (c *Character) isInsidePlatform(p *platforms.Platform) bool {
+	return c.X+c.W/2 > p.X-p.W/2 && c.X-c.W/2 < p.X+p.W/2 && c.Y+c.H/2 > p.Y-p.H/2 && c.Y-c.H/2 < p.Y+p.H/2
+}
+
+// NewBat creates an enemy flying around and hurting on touch
+func NewBat(x, y int32, batTexture *sdl.Texture) *Character {
+	flyingBatRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{0, 0},
+		{1, 0},
+		{2, 0},
+		{3, 0},
+	})
+	hitBatRects := newCharacterAnimationRects([]common.RelativeRectPosition{{4, 0}})
+
+	c := Character{
+		X:             x,
+		Y:             y,
+		W:             constants.TileDestWidth,
+		H:             constants.TileDestHeight,
+		vx:            0,
+		vy:            0,
+		texture:       batTexture,
+		swooshTexture: nil,
+		stamina:       constants.CharacterStaminaMax,
+		health:        constants.DefaultEnemyHealth,
+		time:          0,
+		facedRight:    true,
+		projectiles:   []*projectile{},
+		characterType: enemyBat,
+		faction:       enemyFaction,
+	}
+	c.updateAttack = func(_ []*platforms.Platform, enemies []*Character) {
+		updateTouchAttack(&c, enemies)
+	}
+	flyingBatState := flyingState{
+		character:      &c,
+		animationRects: flyingBatRects,
+	}
+	hitBatState := hitState{
+		character:      &c,
+		animationRects: hitBatRects,
+	}
+	deadBatState := deadState{
+		character:        &c,
+		animationRects:   hitBatRects,
+		landsOnPlatforms: true,
+	}
+	c.flying = &flyingBatState
+	// Bat recovers flying instead of falling down after being hit
+	c.falling = &flyingBatState
+	c.hit = &hitBatState
+	c.dead = &deadBatState
+	c.setState(c.flying)
+	return &c
+}
@@ -0,0 +1,96 @@
+package characters
+
+import (
+	"simpleplatformer/common"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/platforms"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// NewBoss creates a big slasher, that takes many hits to be defeated
+func NewBoss(x, y int32, characterTexture *sdl.Texture, swooshTexture *sdl.Texture) *Character {
+	standingBossRects := newCharacterAnimationRects([]common.RelativeRectPosition{{0, 0}})
+	walkingBossRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{1, 0},
+		{2, 0},
+		{3, 0},
+		{4, 0},
+	})
+	jumpingUpwardBossRects := newCharacterAnimationRects([]common.RelativeRectPosition{{6, 0}})
+	fallingBossRects := newCharacterAnimationRects([]common.RelativeRectPosition{{7, 0}})
+	attackingBossRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{12, 0},
+		{11, 0},
+		{12, 0},
+		{13, 0},
+	})
+	hitBossRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{9, 0},
+		{10, 0},
+	})
+
+	c := Character{
+		X:             x,
+		Y:             y,
+		W:             constants.TileDestWidth * constants.BossScale,
+		H:             constants.TileDestHeight * constants.BossScale,
+		vx:            0,
+		vy:            0,
+		texture:       characterTexture,
+		swooshTexture: swooshTexture,
+		stamina:       constants.CharacterStaminaMax,
+		health:        constants.DefaultBossHealth,
+		time:          0,
+		facedRight:    false,
+		projectiles:   []*projectile{},
+		characterType: enemyBoss,
+		faction:       enemyFaction,
+	}
+	c.newProjectile = newSwooshForCharacter
+	c.updateAttack = func(platforms []*platforms.Platform, enemies []*Character) {
+		updateProjectileAttack(&c, platforms, enemies)
+	}
+	standingBossState := standingState{
+		character:      &c,
+		animationRects: standingBossRects,
+	}
+	walkingBossState := walkingState{
+		character:      &c,
+		animationRects: walkingBossRects,
+	}
+	jumpingBossState := jumpingState{
+		character:      &c,
+		animationRects: jumpingUpwardBossRects,
+	}
+	fallingBossState := fallingState{
+		character:      &c,
+		animationRects: fallingBossRects,
+	}
+	attackingBossState := attackingState{
+		character:      &c,
+		animationRects: attackingBossRects,
+	}
+	hitBossState := hitState{
+		character:      &c,
+		animationRects: hitBossRects,
+	}
+	deadBossState := deadState{
+		character:      &c,
+		animationRects: hitBossRects,
+	}
+	showingAlarmBossState := showingAlarmState{
+		character:      &c,
+		animationRects: standingBossRects,
+	}
+	c.standing = &standingBossState
+	c.walking = &walkingBossState
+	c.jumping = &jumpingBossState
+	c.falling = &fallingBossState
+	c.attacking = &attackingBossState
+	c.hit = &hitBossState
+	c.dead = &deadBossState
+	c.showingAlarm = &showingAlarmBossState
+	c.setState(c.falling)
+	return &c
+}
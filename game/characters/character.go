@@ -31,10 +31,13 @@ type characterState interface {
 	jump()
 	attack()
 	update([]*platforms.Platform, []*ladders.Ladder)
-	hit(float32)
+	hit(Damage)
 	kill(float32)
 	showAlarm()
 	climb(float32, []*ladders.Ladder)
+	fly(float32, float32)
+	dropDown([]*platforms.Platform)
+	swim()
 	getAnimationRects() []*sdl.Rect
 	String() string
 }
@@ -54,16 +57,15 @@ func (s *standingState) move(newVX float32) {
 }
 
 func (s *standingState) jump() {
-	s.character.vy = -constants.JumpSpeed
-	s.character.setState(s.character.jumping)
+	startJump(s.character)
 }
 
 func (s *standingState) attack() {
 	conditionalSwitchToAttackingState(s.character)
 }
 
-func (s *standingState) hit(newVX float32) {
-	prepareAndSetHitState(s.character, newVX)
+func (s *standingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
 }
 
 func (s *standingState) kill(newVX float32) {
@@ -78,7 +80,23 @@ func (s *standingState) climb(newVY float32, lads []*ladders.Ladder) {
 	conditionalClimbLadder(s.character, newVY, lads)
 }
 
-func (s *standingState) update([]*platforms.Platform, []*ladders.Ladder) {}
+func (s *standingState) fly(float32, float32) {}
+
+func (s *standingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *standingState) dropDown(platforms []*platforms.Platform) {
+	conditionalDropDown(s.character, platforms)
+}
+
+func (s *standingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	// The ground can move or disappear under a standing character
+	if !c.stickToGround(platforms) {
+		walkOffEdge(c)
+	}
+}
 
 func (s *standingState) getAnimationRects() []*sdl.Rect {
 	return s.animationRects
@@ -98,17 +116,15 @@ func (s *walkingState) move(newVX float32) {
 }
 
 func (s *walkingState) jump() {
-	c := s.character
-	c.vy = -constants.JumpSpeed
-	c.setState(c.jumping)
+	startJump(s.character)
 }
 
 func (s *walkingState) attack() {
 	conditionalSwitchToAttackingState(s.character)
 }
 
-func (s *walkingState) hit(newVX float32) {
-	prepareAndSetHitState(s.character, newVX)
+func (s *walkingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
 }
 
 func (s *walkingState) kill(newVX float32) {
@@ -123,19 +139,27 @@ func (s *walkingState) climb(newVY float32, lads []*ladders.Ladder) {
 	conditionalClimbLadder(s.character, newVY, lads)
 }
 
+func (s *walkingState) fly(float32, float32) {}
+
+func (s *walkingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *walkingState) dropDown(platforms []*platforms.Platform) {
+	conditionalDropDown(s.character, platforms)
+}
+
 func (s *walkingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
 	c := s.character
 	c.time++
-	for _, p := range platforms {
-		// If character collides with ANY platform from above
-		if c.isTouchingPlatformFromAbove(p) {
-			if c.vx == 0 {
-				c.setState(c.standing)
-			}
-			return
+	// If character collides with ANY platform from above
+	if c.stickToGround(platforms) {
+		if c.vx == 0 {
+			c.setState(c.standing)
 		}
+		return
 	}
-	c.setState(c.falling)
+	walkOffEdge(c)
 }
 
 func (s *walkingState) getAnimationRects() []*sdl.Rect {
@@ -155,12 +179,16 @@ func (s *jumpingState) move(newVX float32) {
 	setVelocityAndSwitchFacedRight(s.character, newVX)
 }
 
-func (s *jumpingState) jump() {}
+func (s *jumpingState) jump() {
+	conditionalDoubleJump(s.character)
+}
 
-func (s *jumpingState) attack() {}
+func (s *jumpingState) attack() {
+	conditionalSwitchToAirAttackingState(s.character)
+}
 
-func (s *jumpingState) hit(newVX float32) {
-	prepareAndSetHitState(s.character, newVX)
+func (s *jumpingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
 }
 
 func (s *jumpingState) kill(newVX float32) {
@@ -175,9 +203,15 @@ func (s *jumpingState) climb(newVY float32, lads []*ladders.Ladder) {
 	conditionalClimbLadder(s.character, newVY, lads)
 }
 
+func (s *jumpingState) fly(float32, float32) {}
+
+func (s *jumpingState) swim() {}
+
+func (s *jumpingState) dropDown([]*platforms.Platform) {}
+
 func (s *jumpingState) update([]*platforms.Platform, []*ladders.Ladder) {
 	s.character.time = 0
-	s.character.vy += constants.Gravity
+	s.character.applyGravity()
 	if s.character.isFalling() {
 		s.character.setState(s.character.falling)
 	}
@@ -200,12 +234,21 @@ func (s *fallingState) move(newVX float32) {
 	setVelocityAndSwitchFacedRight(s.character, newVX)
 }
 
-func (s *fallingState) jump() {}
+// jump is still possible for a moment after walking off an edge
+func (s *fallingState) jump() {
+	if s.character.coyoteTime > 0 {
+		startJump(s.character)
+		return
+	}
+	conditionalDoubleJump(s.character)
+}
 
-func (s *fallingState) attack() {}
+func (s *fallingState) attack() {
+	conditionalSwitchToAirAttackingState(s.character)
+}
 
-func (s *fallingState) hit(newVX float32) {
-	prepareAndSetHitState(s.character, newVX)
+func (s *fallingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
 }
 
 func (s *fallingState) kill(newVX float32) {
@@ -218,15 +261,29 @@ func (s *fallingState) climb(newVY float32, lads []*ladders.Ladder) {
 	conditionalClimbLadder(s.character, newVY, lads)
 }
 
+func (s *fallingState) fly(float32, float32) {}
+
+func (s *fallingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *fallingState) dropDown([]*platforms.Platform) {}
+
 func (s *fallingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
 	c := s.character
 	c.time = 0
-	if c.vy < constants.CharacterVYMax {
-		c.vy += constants.Gravity
-	}
+	c.applyGravity()
 	for _, p := range platforms {
 		if c.isTouchingPlatformFromAbove(p) {
-			c.Y = p.Y - p.H/2 - c.H
+			c.Y = p.SurfaceY(c.X) - c.H
+			if p.IsBouncy() {
+				c.vy = -p.BounceImpulse()
+				// Characters that cannot jump are thrown up while still falling
+				if c.jumping != nil {
+					c.setState(c.jumping)
+				}
+				return
+			}
 			c.vy = 0
 			if c.vx == 0 {
 				c.setState(c.standing)
@@ -235,6 +292,9 @@ func (s *fallingState) update(platforms []*platforms.Platform, _ []*ladders.Ladd
 			}
 		}
 	}
+	if c.currentState == s {
+		conditionalWallSlide(c)
+	}
 }
 
 func (s *fallingState) getAnimationRects() []*sdl.Rect {
@@ -256,8 +316,8 @@ func (s *attackingState) jump() {}
 
 func (s *attackingState) attack() {}
 
-func (s *attackingState) hit(newVX float32) {
-	prepareAndSetHitState(s.character, newVX)
+func (s *attackingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
 }
 
 func (s *attackingState) kill(float32) {
@@ -268,6 +328,12 @@ func (s *attackingState) showAlarm() {}
 
 func (s *attackingState) climb(newVX float32, lads []*ladders.Ladder) {}
 
+func (s *attackingState) fly(float32, float32) {}
+
+func (s *attackingState) swim() {}
+
+func (s *attackingState) dropDown([]*platforms.Platform) {}
+
 func (s *attackingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
 	c := s.character
 	c.vx = 0
@@ -297,7 +363,7 @@ func (s *hitState) jump() {}
 
 func (s *hitState) attack() {}
 
-func (s *hitState) hit(float32) {}
+func (s *hitState) hit(Damage) {}
 
 func (s *hitState) kill(float32) {
 	s.character.setState(s.character.dead)
@@ -307,6 +373,12 @@ func (s *hitState) showAlarm() {}
 
 func (s *hitState) climb(newVX float32, lads []*ladders.Ladder) {}
 
+func (s *hitState) fly(float32, float32) {}
+
+func (s *hitState) swim() {}
+
+func (s *hitState) dropDown([]*platforms.Platform) {}
+
 func (s *hitState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
 	c := s.character
 	if c.health <= 0 {
@@ -314,15 +386,16 @@ func (s *hitState) update(platforms []*platforms.Platform, _ []*ladders.Ladder)
 		return
 	}
 	c.time++
-	c.vy += constants.Gravity
+	c.applyGravity()
 	for _, p := range platforms {
 		if c.isTouchingPlatformFromAbove(p) {
-			c.Y = p.Y - p.H/2 - c.H
+			c.Y = p.SurfaceY(c.X) - c.H
 			c.vy = 0
 		}
 	}
 	if c.time > constants.HitStateLength {
 		c.resetVX()
+		c.invulnerableTime = c.invulnerabilityTime()
 		c.setState(c.falling)
 	}
 }
@@ -346,8 +419,8 @@ func (s *showingAlarmState) jump() {}
 
 func (s *showingAlarmState) attack() {}
 
-func (s *showingAlarmState) hit(newVX float32) {
-	prepareAndSetHitState(s.character, newVX)
+func (s *showingAlarmState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
 }
 
 func (s *showingAlarmState) kill(float32) {
@@ -358,12 +431,18 @@ func (s *showingAlarmState) showAlarm() {}
 
 func (s *showingAlarmState) climb(newVX float32, lads []*ladders.Ladder) {}
 
+func (s *showingAlarmState) fly(float32, float32) {}
+
+func (s *showingAlarmState) swim() {}
+
+func (s *showingAlarmState) dropDown([]*platforms.Platform) {}
+
 func (s *showingAlarmState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
 	c := s.character
-	c.vy += constants.Gravity
+	c.applyGravity()
 	for _, p := range platforms {
 		if c.isTouchingPlatformFromAbove(p) {
-			c.Y = p.Y - p.H/2 - c.H
+			c.Y = p.SurfaceY(c.X) - c.H
 			c.vy = 0
 			c.setState(c.standing)
 		}
@@ -383,18 +462,30 @@ type climbingState struct {
 	animationRects []*sdl.Rect
 }
 
-func (s *climbingState) move(float32) {}
+// move does not let go of the ladder, it picks the side the character jumps off to
+func (s *climbingState) move(newVX float32) {
+	c := s.character
+	c.ladderExitVX = newVX
+	if newVX != 0 {
+		c.facedRight = newVX > 0
+	}
+}
 
 func (s *climbingState) jump() {
 	c := s.character
-	c.vy = 0
-	c.setState(c.jumping)
+	if c.ladderExitVX == 0 {
+		c.vy = 0
+		c.setState(c.jumping)
+		return
+	}
+	startJump(c)
+	c.vx = c.ladderExitVX
 }
 
 func (s *climbingState) attack() {}
 
-func (s *climbingState) hit(newVX float32) {
-	prepareAndSetHitState(s.character, newVX)
+func (s *climbingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
 }
 
 func (s *climbingState) kill(float32) {
@@ -407,6 +498,12 @@ func (s *climbingState) climb(newVY float32, lads []*ladders.Ladder) {
 	s.character.vy = newVY
 }
 
+func (s *climbingState) fly(float32, float32) {}
+
+func (s *climbingState) swim() {}
+
+func (s *climbingState) dropDown([]*platforms.Platform) {}
+
 func (s *climbingState) update(platforms []*platforms.Platform, ladders []*ladders.Ladder) {
 	c := s.character
 	c.vx = 0
@@ -415,15 +512,31 @@ func (s *climbingState) update(platforms []*platforms.Platform, ladders []*ladde
 		c.time = 0
 	} else {
 		c.time++
+		if c.time%constants.LadderStepInterval == 0 {
+			c.publish(LadderStep)
+		}
 	}
 	for _, l := range ladders {
 		if c.isTouchingLadder(l) {
+			c.ladder = l
+			c.moveToLadderMiddle()
+			// Ropes and vines cannot be climbed over
+			if !l.HasTop() && c.Y < l.Y-l.H/2 {
+				c.Y = l.Y - l.H/2
+			}
 			return
 		}
 	}
+	// Climbing up over the top of a ladder puts the character onto it
+	if l := c.ladder; l != nil && l.HasTop() && c.vy < 0 && c.Y+c.H < l.Y-l.H/2 {
+		c.Y = l.Y - l.H/2 - c.H
+		c.vy = 0
+		c.setState(c.standing)
+		return
+	}
 	for _, p := range platforms {
 		if c.isTouchingPlatformFromAbove(p) {
-			c.Y = p.Y - p.H/2 - c.H
+			c.Y = p.SurfaceY(c.X) - c.H
 			c.vy = 0
 			c.setState(c.standing)
 			return
@@ -444,6 +557,8 @@ func (s *climbingState) String() string {
 type deadState struct {
 	character      *Character
 	animationRects []*sdl.Rect
+	// Characters that do not walk (e.g. flying ones) drop on the ground instead of falling out of the screen
+	landsOnPlatforms bool
 }
 
 func (s *deadState) move(float32) {}
@@ -454,16 +569,31 @@ func (s *deadState) attack() {}
 
 func (s *deadState) kill(float32) {}
 
-func (s *deadState) hit(float32) {}
+func (s *deadState) hit(Damage) {}
 
 func (s *deadState) showAlarm() {}
 
 func (s *deadState) climb(newVX float32, lads []*ladders.Ladder) {}
 
-func (s *deadState) update([]*platforms.Platform, []*ladders.Ladder) {
+func (s *deadState) fly(float32, float32) {}
+
+func (s *deadState) swim() {}
+
+func (s *deadState) dropDown([]*platforms.Platform) {}
+
+func (s *deadState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
 	c := s.character
 	c.time++
-	c.vy += constants.Gravity
+	c.applyGravity()
+	if !s.landsOnPlatforms {
+		return
+	}
+	for _, p := range platforms {
+		if c.isTouchingPlatformFromAbove(p) {
+			c.Y = p.SurfaceY(c.X) - c.H
+			c.vx, c.vy = 0, 0
+		}
+	}
 }
 
 func (s *deadState) getAnimationRects() []*sdl.Rect {
@@ -480,25 +610,68 @@ const (
 	player characterType = iota
 	enemySlasher
 	enemySnake
+	enemyArcher
+	enemyBat
+	enemyBoss
 )
 
 type Character struct {
-	X             int32
-	Y             int32
-	W             int32
-	H             int32
-	vy            float32
-	vx            float32
-	texture       *sdl.Texture
-	swooshTexture *sdl.Texture
-	time          int
-	facedRight    bool
-	currentState  characterState
-	swooshes      []*swoosh
-	stamina       int
-	health        int
-	updateAttack  func([]*Character)
-	characterType characterType
+	X                 int32
+	Y                 int32
+	W                 int32
+	H                 int32
+	vy                float32
+	vx                float32
+	texture           *sdl.Texture
+	swooshTexture     *sdl.Texture
+	projectileTexture *sdl.Texture
+	time              int
+	facedRight        bool
+	currentState      characterState
+	projectiles       []*projectile
+	stamina           int
+	staminaBoostTime  int
+	health            int
+	aimX              int32
+	aimY              int32
+	newProjectile     func(*Character) *projectile
+	updateAttack      func([]*platforms.Platform, []*Character)
+	characterType     characterType
+	faction           faction
+	droppingThrough   *platforms.Platform
+	blockedLeft       bool
+	blockedRight      bool
+	// ground is the platform the character stands on, nil if in the air
+	ground *platforms.Platform
+	// remainderX keeps the fraction of a pixel the character should have moved by
+	remainderX float32
+	// inWater is set every frame the character is in the water with the surface at waterSurfaceY
+	inWater        bool
+	waterSurfaceY  int32
+	timeUnderwater int
+	// events are published by the state transitions until the game takes them
+	events []Event
+	// attacker is the character who hit this one last, nil if it was a hazard
+	attacker *Character
+	// invulnerableTime counts down the frames the character cannot be hurt for after a hit
+	invulnerableTime int
+	// comboStep is the hit of the combo being done, comboBuffered is set when the next one was asked for
+	comboStep     int
+	comboBuffered bool
+	weapon        Weapon
+	// variableJump is set for jumps which can be cut short, coyoteTime and jumpBuffer count down frames
+	// left for jumping after walking off an edge and for doing a jump pressed before landing
+	variableJump bool
+	coyoteTime   int
+	jumpBuffer   int
+	// ladder is the one climbed last, ladderExitVX the speed of jumping off it to the side
+	ladder       *ladders.Ladder
+	ladderExitVX float32
+	// abilities are the unlocked ones, airJumped and airDashed are set until the character lands
+	abilities    uint
+	airJumped    bool
+	airDashed    bool
+	dashCooldown int
 
 	standing     characterState
 	walking      characterState
@@ -509,6 +682,23 @@ type Character struct {
 	falling      characterState
 	climbing     characterState
 	showingAlarm characterState
+	flying       characterState
+	swimming     characterState
+	// Moves only the player can do
+	comboAttacking characterState
+	airAttacking   characterState
+	pogoing        characterState
+	blocking       characterState
+	charging       characterState
+	doubleJumping  characterState
+	wallSliding    characterState
+	wallJumping    characterState
+	dashing        characterState
+}
+
+// Position returns where the character is, it's where its sounds come from
+func (c *Character) Position() (x, y int32) {
+	return c.X, c.Y
 }
 
 // IsPlayer returns true if the character is of player type
@@ -526,12 +716,29 @@ func (c *Character) IsEnemySnake() bool {
 	return c.characterType == enemySnake
 }
 
+// IsEnemyArcher returns true if the character is of enemy archer type
+func (c *Character) IsEnemyArcher() bool {
+	return c.characterType == enemyArcher
+}
+
+// IsEnemyBat returns true if the character is of enemy bat type
+func (c *Character) IsEnemyBat() bool {
+	return c.characterType == enemyBat
+}
+
+// IsEnemyBoss returns true if the character is of enemy boss type
+func (c *Character) IsEnemyBoss() bool {
+	return c.characterType == enemyBoss
+}
+
 func (c *Character) setState(s characterState) {
+	c.publishTransition(s)
 	c.time = 0
 	c.currentState = s
+	c.variableJump = false
 }
 
-func NewPlayerCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture *sdl.Texture) *Character {
+func NewPlayerCharacter(x, y int32, characterTexture, swooshTexture, projectileTexture *sdl.Texture) *Character {
 	standingPlayerRects := newCharacterAnimationRects([]common.RelativeRectPosition{{0, 1}})
 	walkingPlayerRects := newCharacterAnimationRects([]common.RelativeRectPosition{
 		{1, 1},
@@ -557,6 +764,18 @@ func NewPlayerCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture
 		{21, 1},
 		{22, 1},
 	})
+	pogoingPlayerRects := newCharacterAnimationRects([]common.RelativeRectPosition{{8, 1}})
+	blockingPlayerRects := newCharacterAnimationRects([]common.RelativeRectPosition{{14, 1}})
+	chargingPlayerRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{15, 1},
+		{16, 1},
+	})
+	doubleJumpingPlayerRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{5, 1},
+		{6, 1},
+	})
+	wallSlidingPlayerRects := newCharacterAnimationRects([]common.RelativeRectPosition{{17, 1}})
+	dashingPlayerRects := newCharacterAnimationRects([]common.RelativeRectPosition{{18, 1}})
 
 	c := Character{
 		X:             x,
@@ -571,11 +790,15 @@ func NewPlayerCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture
 		health:        constants.DefaultPlayerHealth,
 		time:          0,
 		facedRight:    true,
-		swooshes:      []*swoosh{},
+		projectiles:   []*projectile{},
 		characterType: player,
+		faction:       playerFaction,
 	}
-	c.updateAttack = func(enemies []*Character) {
-		updateSwooshAttack(&c, enemies)
+	// Thrown weapons use the projectile texture
+	c.projectileTexture = projectileTexture
+	c.Equip(Sword)
+	c.updateAttack = func(platforms []*platforms.Platform, enemies []*Character) {
+		updateProjectileAttack(&c, platforms, enemies)
 	}
 	standingPlayerState := standingState{
 		character:      &c,
@@ -605,10 +828,44 @@ func NewPlayerCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture
 		character:      &c,
 		animationRects: climbingPlayerRects,
 	}
+	swimmingPlayerState := swimmingState{
+		character:      &c,
+		animationRects: walkingPlayerRects,
+	}
 	deadPlayerState := deadState{
 		character:      &c,
 		animationRects: hitPlayerRects,
 	}
+	comboPlayerState := comboState{character: &c}
+	airAttackingPlayerState := airAttackingState{character: &c}
+	pogoingPlayerState := pogoingState{
+		character:      &c,
+		animationRects: pogoingPlayerRects,
+	}
+	blockingPlayerState := blockingState{
+		character:      &c,
+		animationRects: blockingPlayerRects,
+	}
+	chargingPlayerState := chargingState{
+		character:      &c,
+		animationRects: chargingPlayerRects,
+	}
+	doubleJumpingPlayerState := doubleJumpingState{
+		character:      &c,
+		animationRects: doubleJumpingPlayerRects,
+	}
+	wallSlidingPlayerState := wallSlidingState{
+		character:      &c,
+		animationRects: wallSlidingPlayerRects,
+	}
+	wallJumpingPlayerState := wallJumpingState{
+		character:      &c,
+		animationRects: jumpingUpwardPlayerRects,
+	}
+	dashingPlayerState := dashingState{
+		character:      &c,
+		animationRects: dashingPlayerRects,
+	}
 	c.standing = &standingPlayerState
 	c.walking = &walkingPlayerState
 	c.jumping = &jumpingPlayerState
@@ -617,6 +874,16 @@ func NewPlayerCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture
 	c.hit = &hitPlayerState
 	c.climbing = &climbingPlayerState
 	c.dead = &deadPlayerState
+	c.swimming = &swimmingPlayerState
+	c.comboAttacking = &comboPlayerState
+	c.airAttacking = &airAttackingPlayerState
+	c.pogoing = &pogoingPlayerState
+	c.blocking = &blockingPlayerState
+	c.charging = &chargingPlayerState
+	c.doubleJumping = &doubleJumpingPlayerState
+	c.wallSliding = &wallSlidingPlayerState
+	c.wallJumping = &wallJumpingPlayerState
+	c.dashing = &dashingPlayerState
 	c.setState(c.falling)
 	return &c
 }
@@ -641,6 +908,12 @@ func NewEnemyCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture
 		{9, 0},
 		{10, 0},
 	})
+	climbingEnemyRects := newCharacterAnimationRects([]common.RelativeRectPosition{
+		{19, 0},
+		{20, 0},
+		{21, 0},
+		{22, 0},
+	})
 
 	c := Character{
 		X:             x,
@@ -655,11 +928,13 @@ func NewEnemyCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture
 		health:        constants.DefaultEnemyHealth,
 		time:          0,
 		facedRight:    true,
-		swooshes:      []*swoosh{},
+		projectiles:   []*projectile{},
 		characterType: enemySlasher,
+		faction:       enemyFaction,
 	}
-	c.updateAttack = func(enemies []*Character) {
-		updateSwooshAttack(&c, enemies)
+	c.newProjectile = newSwooshForCharacter
+	c.updateAttack = func(platforms []*platforms.Platform, enemies []*Character) {
+		updateProjectileAttack(&c, platforms, enemies)
 	}
 	standingEnemyState := standingState{
 		character:      &c,
@@ -685,6 +960,14 @@ func NewEnemyCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture
 		character:      &c,
 		animationRects: hitEnemyRects,
 	}
+	climbingEnemyState := climbingState{
+		character:      &c,
+		animationRects: climbingEnemyRects,
+	}
+	swimmingEnemyState := swimmingState{
+		character:      &c,
+		animationRects: walkingEnemyRects,
+	}
 	deadEnemyState := deadState{
 		character:      &c,
 		animationRects: hitEnemyRects,
@@ -700,43 +983,88 @@ func NewEnemyCharacter(x, y int32, characterTexture *sdl.Texture, swooshTexture
 	c.attacking = &attackingEnemyState
 	c.hit = &hitEnemyState
 	c.dead = &deadEnemyState
+	c.swimming = &swimmingEnemyState
+	c.climbing = &climbingEnemyState
 	c.showingAlarm = &showingAlarmEnemyState
 	c.setState(c.falling)
 	return &c
 }
 
 func (c *Character) Update(platforms []*platforms.Platform, ladders []*ladders.Ladder, enemies []*Character) {
-	c.X += int32(c.vx)
+	c.followMovingPlatforms(platforms)
+	dx := c.vx + c.remainderX
+	c.X += int32(dx)
+	c.remainderX = dx - float32(int32(dx))
 	c.Y += int32(c.vy)
-	if !c.CanAttack() {
+	c.collideWithSolidPlatforms(platforms)
+	if c.droppingThrough != nil && c.Y+c.H > c.droppingThrough.Y-c.droppingThrough.H/2+5 {
+		c.droppingThrough = nil
+	}
+	// Stamina spent on attacks comes back once the attack is over
+	if c.stamina < constants.CharacterStaminaMax && !c.isAttacking() {
 		c.stamina++
+		if c.staminaBoostTime > 0 {
+			c.stamina += 2
+		}
+		if c.stamina > constants.CharacterStaminaMax {
+			c.stamina = constants.CharacterStaminaMax
+		}
+	}
+	if c.staminaBoostTime > 0 {
+		c.staminaBoostTime--
+	}
+	if c.invulnerableTime > 0 {
+		c.invulnerableTime--
 	}
 	c.currentState.update(platforms, ladders)
-	c.updateAttack(enemies)
+	c.updateJumpTimers()
+	c.updateAttack(platforms, enemies)
+	c.updateBreath()
+	c.ground = nil
+	for _, p := range platforms {
+		if c.IsStandingOn(p) {
+			c.ground = p
+			break
+		}
+	}
+	c.updateAbilities()
 }
 
-func updateSwooshAttack(c *Character, enemies []*Character) {
-	for _, s := range c.swooshes {
-		for _, e := range enemies {
-			if c == e {
-				continue
-			}
-			if (s.y+s.h/2) > (e.Y-e.H/2) && (s.y-s.h/2) < (e.Y+e.H/2) { // Touches enemy vertically
-				if (s.x+s.w/2) > (e.X-e.W/2) && (s.x-s.w/2) < (e.X+e.W/2) { // Touches enemy horizontally
-					e.Hit(s.vx)
-					s.destroyed = true
-					break
-				}
-			}
-		}
+func updateProjectileAttack(c *Character, platforms []*platforms.Platform, enemies []*Character) {
+	for _, p := range c.projectiles {
+		p.hitCharacters(enemies)
+	}
+	c.projectiles = updateProjectiles(c.projectiles, platforms)
+}
+
+// Shift moves the character together with its projectiles
+func (c *Character) Shift(dx, dy int32) {
+	c.X += dx
+	c.Y += dy
+	for _, p := range c.projectiles {
+		p.shift(dx, dy)
 	}
-	c.swooshes = updateSwooshes(c.swooshes)
+}
+
+// AimAt sets the point the next projectile shot by the character should land at
+func (c *Character) AimAt(x, y int32) {
+	c.aimX, c.aimY = x, y
+	c.facedRight = x > c.X
 }
 
 func (c *Character) CanAttack() bool {
+	return c.stamina >= c.attackCost()
+}
+
+func (c *Character) hasFullStamina() bool {
 	return c.stamina >= constants.CharacterStaminaMax
 }
 
+func (c *Character) isAttacking() bool {
+	s := c.currentState
+	return s == c.attacking || s == c.comboAttacking || s == c.airAttacking || s == c.pogoing
+}
+
 func (c *Character) reset() {
 	c.X, c.Y = 0, 0
 	c.vx, c.vy = 0, 0
@@ -747,7 +1075,146 @@ func (c *Character) resetVX() {
 }
 
 func (c *Character) isTouchingPlatformFromAbove(p *platforms.Platform) bool {
-	return c.Y+c.H >= p.Y-p.H/2 && c.Y+c.H <= p.Y-p.H/2+5 && c.X >= p.X-p.W/2 && c.X <= p.X+p.W/2
+	if p == c.droppingThrough {
+		return false
+	}
+	top := p.SurfaceY(c.X)
+	return c.Y+c.H >= top && c.Y+c.H <= top+5 && c.X >= p.X-p.W/2 && c.X <= p.X+p.W/2
+}
+
+// stickToGround puts the character on the surface of the platform it walks on, so it does not fall when going down the slope
+func (c *Character) stickToGround(platforms []*platforms.Platform) bool {
+	for _, p := range platforms {
+		if p == c.droppingThrough || c.X < p.X-p.W/2 || c.X > p.X+p.W/2 {
+			continue
+		}
+		top := p.SurfaceY(c.X)
+		stickDistance := int32(0)
+		if p.IsSlope() {
+			stickDistance = constants.SlopeStickDistance
+		}
+		if c.Y+c.H >= top-stickDistance && c.Y+c.H <= top+5 {
+			c.Y = top - c.H
+			return true
+		}
+	}
+	return false
+}
+
+// hasGroundAt returns true if there is a platform at x the character could walk onto
+func (c *Character) hasGroundAt(x int32, platforms []*platforms.Platform) bool {
+	for _, p := range platforms {
+		if p == c.droppingThrough || x < p.X-p.W/2 || x > p.X+p.W/2 {
+			continue
+		}
+		// Slopes are at most 45 degrees, so the ground can't be further than the distance to x
+		distance := p.SurfaceY(x) - (c.Y + c.H)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance <= c.W/2+5 {
+			return true
+		}
+	}
+	return false
+}
+
+// wasTouchingPlatformFromAbove returns true if the character stood on the platform before its last move
+func (c *Character) wasTouchingPlatformFromAbove(p *platforms.Platform) bool {
+	top := p.SurfaceY(c.X-p.DX) - p.DY
+	x := p.X - p.DX
+	return c.Y+c.H >= top && c.Y+c.H <= top+5 && c.X >= x-p.W/2 && c.X <= x+p.W/2
+}
+
+// followMovingPlatforms carries the character standing on a moving platform and pushes it away from platforms moving into it
+func (c *Character) followMovingPlatforms(platforms []*platforms.Platform) {
+	if c.currentState == c.climbing || c.currentState == c.flying || c.isJumpingUpward() {
+		return
+	}
+	for _, p := range platforms {
+		if (p.DX != 0 || p.DY != 0) && c.wasTouchingPlatformFromAbove(p) {
+			c.X += p.DX
+			c.Y += p.DY
+			return
+		}
+	}
+	for _, p := range platforms {
+		if p.DX == 0 || c.Y+c.H <= p.SurfaceY(c.X)+5 || c.Y-c.H/2 >= p.Y+p.H/2 {
+			continue
+		}
+		// Check the whole area the platform went through, so fast platforms do not pass through the character
+		left := p.X - p.W/2 - c.W/2
+		right := p.X + p.W/2 + c.W/2
+		if p.DX > 0 {
+			left -= p.DX
+		} else {
+			right -= p.DX
+		}
+		if c.X <= left || c.X >= right {
+			continue
+		}
+		if p.DX > 0 {
+			c.X = p.X + p.W/2 + c.W/2
+		} else {
+			c.X = p.X - p.W/2 - c.W/2
+		}
+	}
+}
+
+// collideWithSolidPlatforms moves the character out of the solid platforms it walked or jumped into
+func (c *Character) collideWithSolidPlatforms(platforms []*platforms.Platform) {
+	c.blockedLeft, c.blockedRight = false, false
+	if c.currentState == c.climbing || c.currentState == c.flying || c.currentState == c.dead {
+		return
+	}
+	// Character does not take the whole sprite width
+	halfW := c.W / 4
+	prevX := c.X - int32(c.vx)
+	prevY := c.Y - int32(c.vy)
+	for _, p := range platforms {
+		if !p.IsSolid() {
+			continue
+		}
+		top, bottom := p.SurfaceY(c.X), p.Y+p.H/2
+		left, right := p.X-p.W/2, p.X+p.W/2
+		if c.X+halfW <= left || c.X-halfW >= right || c.Y-c.H/2 >= bottom || c.Y+c.H <= top+5 {
+			continue
+		}
+		switch {
+		case prevY+c.H <= top+5:
+			// Fell too fast to be caught by the landing check
+			c.Y = top - c.H
+		case prevY-c.H/2 >= bottom:
+			c.Y = bottom + c.H/2
+			c.vy = 0
+		case prevX < p.X:
+			c.X = left - halfW
+			c.blockedRight = true
+		default:
+			c.X = right + halfW
+			c.blockedLeft = true
+		}
+	}
+}
+
+// DropDown makes the character fall through the platform it stands on, if it's possible
+func (c *Character) DropDown(platforms []*platforms.Platform) {
+	c.currentState.dropDown(platforms)
+}
+
+// CanDropDown returns true if the character stands on a platform it can drop through
+func (c *Character) CanDropDown(platforms []*platforms.Platform) bool {
+	for _, p := range platforms {
+		if p.CanDropThrough() && c.IsStandingOn(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStandingOn returns true if the character stands on the platform
+func (c *Character) IsStandingOn(p *platforms.Platform) bool {
+	return !c.isJumpingUpward() && c.currentState != c.climbing && c.isTouchingPlatformFromAbove(p)
 }
 
 func (c *Character) isTouchingLadder(l *ladders.Ladder) bool {
@@ -756,6 +1223,28 @@ func (c *Character) isTouchingLadder(l *ladders.Ladder) bool {
 	return c.X > l.X-l.W/2 && c.X < l.X+l.W/2 && c.Y >= l.Y-l.H/2-c.H && c.Y+c.H <= l.Y+l.H/2
 }
 
+// moveToLadderMiddle brings the climbing character to the middle of its ladder, a bit every frame
+func (c *Character) moveToLadderMiddle() {
+	dx := c.ladder.X - c.X
+	if dx > constants.LadderGrabSpeed {
+		dx = constants.LadderGrabSpeed
+	} else if dx < -constants.LadderGrabSpeed {
+		dx = -constants.LadderGrabSpeed
+	}
+	c.X += dx
+}
+
+// IsClimbing returns true if the character is on a ladder
+func (c *Character) IsClimbing() bool {
+	return c.climbing != nil && c.currentState == c.climbing
+}
+
+// IsAtRopeTop returns true if the character hangs at the top of a rope or vine, it cannot climb any higher
+func (c *Character) IsAtRopeTop() bool {
+	l := c.ladder
+	return c.IsClimbing() && l != nil && !l.HasTop() && c.Y <= l.Y-l.H/2
+}
+
 func (c *Character) isFalling() bool {
 	return c.vy > 0
 }
@@ -792,24 +1281,78 @@ func (c *Character) IsFacedRight() bool {
 	return c.facedRight
 }
 
+// IsCloseToPlatformLeftEdge returns true if the character is about to walk off the platform or is blocked on the left
 func (c *Character) IsCloseToPlatformLeftEdge(platforms []*platforms.Platform) bool {
 	for _, p := range platforms {
 		if c.isTouchingPlatformFromAbove(p) {
-			return c.X < (p.X - p.W/2 + c.W/2)
+			return c.blockedLeft || (c.X < (p.X-p.W/2+c.W/2) && !c.hasGroundAt(c.X-c.W/2, platforms))
 		}
 	}
 	return false
 }
 
+// IsCloseToPlatformRightEdge returns true if the character is about to walk off the platform or is blocked on the right
 func (c *Character) IsCloseToPlatformRightEdge(platforms []*platforms.Platform) bool {
 	for _, p := range platforms {
 		if c.isTouchingPlatformFromAbove(p) {
-			return c.X > (p.X + p.W/2 - c.W/2)
+			return c.blockedRight || (c.X > (p.X+p.W/2-c.W/2) && !c.hasGroundAt(c.X+c.W/2, platforms))
 		}
 	}
 	return false
 }
 
+// Health returns the number of hits the character can still take
+func (c *Character) Health() int {
+	return c.health
+}
+
+// MaxHealth returns the health the character starts with
+func (c *Character) MaxHealth() int {
+	switch c.characterType {
+	case player:
+		return constants.DefaultPlayerHealth
+	case enemyBoss:
+		return constants.DefaultBossHealth
+	}
+	return constants.DefaultEnemyHealth
+}
+
+// Heal restores the character health up to its maximum
+func (c *Character) Heal(amount int) {
+	if c.IsDead() {
+		return
+	}
+	c.health += amount
+	if c.health > c.MaxHealth() {
+		c.health = c.MaxHealth()
+	}
+}
+
+// BoostStamina makes the character recover stamina faster for a while
+func (c *Character) BoostStamina() {
+	c.stamina = constants.CharacterStaminaMax
+	c.staminaBoostTime = constants.StaminaBoostLength
+}
+
+// StateName returns the name of the current character state
+func (c *Character) StateName() string {
+	return c.currentState.String()
+}
+
+// HitBox returns the rectangle used to check if the character got hit
+func (c *Character) HitBox() sdl.Rect {
+	return sdl.Rect{c.X - c.W/2, c.Y - c.H/2, c.W, c.H}
+}
+
+// ProjectileHitBoxes returns the rectangles of projectiles currently shot by the character
+func (c *Character) ProjectileHitBoxes() []sdl.Rect {
+	result := []sdl.Rect{}
+	for _, p := range c.projectiles {
+		result = append(result, p.hitBox())
+	}
+	return result
+}
+
 func (c *Character) FinishedShowingAlarm() bool {
 	return c.currentState == c.standing
 }
@@ -862,19 +1405,94 @@ func (c *Character) Move(newVX float32) {
 	c.currentState.move(newVX)
 }
 
+// Jump is called when the jump button is pressed, a jump that cannot be done yet is buffered
 func (c *Character) Jump() {
+	c.jumpBuffer = physics.JumpBufferTime
 	c.currentState.jump()
 }
 
+// ReleaseJump cuts the jump short, so that holding the button longer jumps higher
+func (c *Character) ReleaseJump() {
+	if c.currentState == c.jumping && c.variableJump && c.vy < -physics.JumpCutSpeed {
+		c.vy = -physics.JumpCutSpeed
+	}
+}
+
 func (c *Character) Attack() {
 	c.currentState.attack()
 }
 
-func (c *Character) Hit(newVX float32) {
-	c.currentState.hit(newVX)
+// AttackDown thrusts the weapon downwards when in the air, otherwise it is a regular attack
+func (c *Character) AttackDown() {
+	if c.pogoing == nil || (c.currentState != c.jumping && c.currentState != c.falling) {
+		c.Attack()
+		return
+	}
+	if !c.CanAttack() {
+		return
+	}
+	c.stamina -= c.attackCost()
+	c.projectiles = append(c.projectiles, newPogoThrust(c))
+	c.setState(c.pogoing)
+}
+
+// Block raises the guard while held, only on the ground
+func (c *Character) Block(held bool) {
+	if c.blocking == nil {
+		return
+	}
+	if held && (c.currentState == c.standing || c.currentState == c.walking) {
+		c.vx = 0
+		c.setState(c.blocking)
+	} else if !held && c.currentState == c.blocking {
+		c.setState(c.standing)
+	}
+}
+
+// Charge builds up the heavy attack while held with full stamina, releasing it attacks if it got charged
+func (c *Character) Charge(held bool) {
+	if c.charging == nil {
+		return
+	}
+	if held && c.hasFullStamina() && (c.currentState == c.standing || c.currentState == c.walking) {
+		c.vx = 0
+		c.setState(c.charging)
+	} else if !held && c.currentState == c.charging {
+		if c.IsCharged() {
+			heavyAttack(c)
+		} else {
+			c.setState(c.standing)
+		}
+	}
+}
+
+// Hit hurts the character with damage not caused by another character
+func (c *Character) Hit(d Damage) {
+	c.hitBy(nil, d)
+}
+
+// hitBy hits the character on behalf of the attacker, who is remembered only if the hit got through.
+// It returns true if the hit got through.
+func (c *Character) hitBy(attacker *Character, d Damage) bool {
+	if c.IsInvulnerable() && d.Type != Drowning {
+		return false
+	}
+	d = c.resist(d)
+	if d.Amount <= 0 {
+		return false
+	}
+	previous, lastAttacker := c.currentState, c.attacker
+	c.attacker = attacker
+	c.currentState.hit(d)
+	if c.currentState == previous {
+		c.attacker = lastAttacker
+		return false
+	}
+	return true
 }
 
 func (c *Character) Kill(newVX float32) {
+	c.attacker = nil
 	c.currentState.kill(newVX)
 }
 
@@ -886,12 +1504,24 @@ func (c *Character) Climb(newVY float32, lads []*ladders.Ladder) {
 	c.currentState.climb(newVY, lads)
 }
 
+func (c *Character) Fly(newVX, newVY float32) {
+	c.currentState.fly(newVX, newVY)
+}
+
+// Swim needs to be called every frame the character is in the water
+func (c *Character) Swim(surfaceY int32) {
+	c.inWater = true
+	c.waterSurfaceY = surfaceY
+	c.currentState.swim()
+}
+
 func (c *Character) Draw(renderer *sdl.Renderer) {
 	currentAnimationRects := c.currentState.getAnimationRects()
 	displayedFrame := c.time / 10 % len(currentAnimationRects)
 	src := currentAnimationRects[displayedFrame]
-	characterDestWidth := constants.CharacterDestWidth
-	characterDestHeight := constants.CharacterDestHeight
+	// Characters bigger than a tile are drawn scaled up
+	characterDestWidth := constants.CharacterDestWidth * c.W / constants.TileDestWidth
+	characterDestHeight := constants.CharacterDestHeight * c.H / constants.TileDestHeight
 	dst := &sdl.Rect{c.X - characterDestWidth/2, c.Y - characterDestHeight/2, characterDestWidth, characterDestHeight}
 	var flip sdl.RendererFlip
 	if c.facedRight {
@@ -899,12 +1529,19 @@ func (c *Character) Draw(renderer *sdl.Renderer) {
 	} else {
 		flip = sdl.FLIP_HORIZONTAL
 	}
-	err := renderer.CopyEx(c.texture, src, dst, 0, nil, flip)
-	if err != nil {
-		log.Fatalf("could not copy Character texture: %v", err)
+	// The character flashes while invulnerable and glows when the heavy attack is charged
+	if c.IsCharged() && c.time/constants.InvulnerableBlink%2 == 0 {
+		c.texture.SetColorMod(255, 230, 120)
+		defer c.texture.SetColorMod(255, 255, 255)
+	}
+	if c.invulnerableTime/constants.InvulnerableBlink%2 == 0 {
+		err := renderer.CopyEx(c.texture, src, dst, 0, nil, flip)
+		if err != nil {
+			log.Fatalf("could not copy Character texture: %v", err)
+		}
 	}
-	// Draw swooshes made by character
-	for _, s := range c.swooshes {
-		s.draw(renderer)
+	// Draw projectiles shot by character
+	for _, p := range c.projectiles {
+		p.draw(renderer)
 	}
 }
@@ -0,0 +1,30 @@
+package characters
+
+import (
+	"simpleplatformer/constants"
+	"simpleplatformer/game/ladders"
+	"testing"
+)
+
+func TestClimbToRopeTop(t *testing.T) {
+	rope, err := ladders.NewLadder(100, 200, constants.TileDestWidth, 3*constants.TileDestHeight, ladders.Rope, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lads := []*ladders.Ladder{&rope}
+	c := NewPlayerCharacter(100, 200, nil, nil, nil)
+	c.Climb(-constants.CharacterVY, lads)
+	if !c.IsClimbing() {
+		t.Fatalf("got %v, want climbing", c.currentState)
+	}
+	if c.IsAtRopeTop() {
+		t.Fatal("at the rope top right after grabbing the middle of it")
+	}
+	for i := 0; i < 100; i++ {
+		c.Climb(-constants.CharacterVY, lads)
+		c.currentState.update(nil, lads)
+	}
+	if !c.IsClimbing() || !c.IsAtRopeTop() {
+		t.Errorf("got %v at y %v, want hanging at the rope top %v", c.currentState, c.Y, rope.Y-rope.H/2)
+	}
+}
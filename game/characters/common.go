@@ -3,24 +3,30 @@ package characters
 import (
 	"simpleplatformer/constants"
 	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
 )
 
 func conditionalSwitchToAttackingState(c *Character) {
 	if !c.CanAttack() {
 		return
 	}
-	c.swooshes = append(c.swooshes, newSwooshForCharacter(c))
+	// The player starts a combo instead of a single swing
+	if c.comboAttacking != nil {
+		comboHit(c, 1)
+		return
+	}
+	c.projectiles = append(c.projectiles, c.newProjectile(c))
 	c.setState(c.attacking)
 }
 
-func prepareAndSetHitState(c *Character, newVX float32) {
+func prepareAndSetHitState(c *Character, d Damage) {
 	c.facedRight = true
-	if newVX > 0 {
+	if d.KnockbackVX > 0 {
 		c.facedRight = false
 	}
-	c.vx = newVX
-	c.vy = constants.CharacterVYWhenHit
-	c.health--
+	c.vx = d.KnockbackVX
+	c.vy = d.KnockbackVY
+	c.health -= d.Amount
 	c.setState(c.hit)
 }
 
@@ -41,18 +47,54 @@ func setVelocityAndSwitchFacedRight(c *Character, newVX float32) {
 	} else if newVX < 0 {
 		c.facedRight = false
 	}
+	if c.ground != nil && c.ground.IsSlippery() {
+		// Speeding up and slowing down takes a while on ice
+		c.vx += (newVX - c.vx) * constants.IceGrip
+		if newVX == 0 && c.vx > -constants.IceStopVX && c.vx < constants.IceStopVX {
+			c.vx = 0
+		}
+		return
+	}
 	c.vx = newVX
 }
 
+// conditionalClimbLadder grabs the ladder the character touches, also in the air. The character
+// is moved to the middle of the ladder while climbing, so it does not jump to it.
 func conditionalClimbLadder(c *Character, newVY float32, lads []*ladders.Ladder) {
-	if newVY == 0 {
+	// Characters that cannot climb just walk past the ladders
+	if newVY == 0 || c.climbing == nil {
 		return
 	}
 	for _, l := range lads {
+		// There is nothing to climb up to from the top of the ladder
+		if newVY < 0 && c.Y+c.H <= l.Y-l.H/2 {
+			continue
+		}
 		if c.isTouchingLadder(l) {
-			c.X = l.X
+			c.ladder = l
+			c.ladderExitVX = 0
 			c.vy = newVY
 			c.setState(c.climbing)
+			return
 		}
 	}
 }
+
+func conditionalDropDown(c *Character, platforms []*platforms.Platform) {
+	for _, p := range platforms {
+		if p.CanDropThrough() && c.isTouchingPlatformFromAbove(p) {
+			c.droppingThrough = p
+			c.setState(c.falling)
+			return
+		}
+	}
+}
+
+func conditionalSwim(c *Character) {
+	// Characters that cannot swim just walk under the water
+	if c.swimming == nil {
+		return
+	}
+	c.time = 0
+	c.setState(c.swimming)
+}
@@ -0,0 +1,103 @@
+package characters
+
+import "simpleplatformer/constants"
+
+// DamageType tells what hurt the character, some characters resist some types better than others
+type DamageType int
+
+const (
+	Slash DamageType = iota
+	Pierce
+	Bite
+	Spikes
+	// Drowning is not stopped by the invulnerability after a hit
+	Drowning
+)
+
+// Damage is what a hit does to the character
+type Damage struct {
+	Amount int
+	Type   DamageType
+	// KnockbackVX is the horizontal speed the character gets pushed with, its sign gives the direction
+	KnockbackVX float32
+	KnockbackVY float32
+}
+
+// Towards returns the damage with the knockback pushing the character in the direction of dx
+func (d Damage) Towards(dx float32) Damage {
+	if (dx < 0) != (d.KnockbackVX < 0) {
+		d.KnockbackVX = -d.KnockbackVX
+	}
+	return d
+}
+
+// resistance is the percentage of the damage amount and of the knockback the character takes
+type resistance struct {
+	damage    int
+	knockback int
+}
+
+// resistances of the character types, damage types left out are taken in full
+var resistances = map[characterType]map[DamageType]resistance{
+	enemyBat: {
+		Spikes: {0, 0},
+	},
+	enemyBoss: {
+		Slash:  {100, 25},
+		Pierce: {50, 0},
+		Spikes: {50, 0},
+	},
+}
+
+// resist returns the damage the character really takes, at least 1 unless it is immune
+func (c *Character) resist(d Damage) Damage {
+	r, ok := resistances[c.characterType][d.Type]
+	if !ok {
+		return d
+	}
+	d.Amount = (d.Amount*r.damage + 99) / 100
+	d.KnockbackVX = d.KnockbackVX * float32(r.knockback) / 100
+	d.KnockbackVY = d.KnockbackVY * float32(r.knockback) / 100
+	return d
+}
+
+// invulnerabilityTime returns for how long the character cannot be hurt after recovering from a hit
+func (c *Character) invulnerabilityTime() int {
+	switch c.characterType {
+	case player:
+		return constants.PlayerInvulnerable
+	case enemyBoss:
+		return constants.BossInvulnerable
+	}
+	return constants.EnemyInvulnerable
+}
+
+// IsInvulnerable returns true if the character has just recovered from a hit and cannot be hurt
+func (c *Character) IsInvulnerable() bool {
+	return c.invulnerableTime > 0
+}
+
+func swooshDamage(c *Character) Damage {
+	amount := constants.SwooshDamage
+	if c.IsEnemyBoss() {
+		amount = constants.BossSwooshDamage
+	}
+	return Damage{amount, Slash, constants.SwooshKnockbackVX, constants.CharacterVYWhenHit}
+}
+
+func arrowDamage() Damage {
+	return Damage{constants.ArrowDamage, Pierce, constants.ArrowKnockbackVX, constants.CharacterVYWhenHit}
+}
+
+func biteDamage() Damage {
+	return Damage{constants.BiteDamage, Bite, constants.BiteKnockbackVX, constants.CharacterVYWhenHit}
+}
+
+func drowningDamage() Damage {
+	return Damage{constants.DrownDamage, Drowning, 0, constants.CharacterVYWhenHit}
+}
+
+// SpikesDamage returns the damage done by spikes
+func SpikesDamage() Damage {
+	return Damage{constants.SpikesDamage, Spikes, constants.SpikesKnockbackVX, constants.CharacterVYWhenHit}
+}
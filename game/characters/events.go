@@ -0,0 +1,72 @@
+package characters
+
+type EventKind int
+
+const (
+	// Jumped is published when the character starts a jump, also when bouncing or jumping out of the water
+	Jumped EventKind = iota
+	// Attacked is published when the character swings a weapon or shoots, or when a snake bites
+	Attacked
+	// Hurt is published when the character gets hit but survives
+	Hurt
+	Died
+	// Alarmed is published when an enemy notices the player
+	Alarmed
+	// Landed is published when the character lands after a fall
+	Landed
+	// LadderStep is published regularly while the character climbs
+	LadderStep
+	// Blocked is published when the character blocks a hit with its guard, Parried when it parries it
+	Blocked
+	Parried
+)
+
+// Event is something that happened to the character, the game takes them every frame
+type Event struct {
+	Kind      EventKind
+	Character *Character
+	// Attacker is the character who hurt, killed or got blocked by this one, nil for hazards
+	Attacker *Character
+}
+
+func (c *Character) publish(kind EventKind) {
+	e := Event{Kind: kind, Character: c}
+	if kind == Hurt || kind == Died || kind == Blocked || kind == Parried {
+		e.Attacker = c.attacker
+	}
+	c.events = append(c.events, e)
+}
+
+// publishTransition publishes the event caused by switching from the current state to the next one
+func (c *Character) publishTransition(next characterState) {
+	if c.currentState == nil || next == c.currentState {
+		return
+	}
+	switch next {
+	case c.jumping, c.doubleJumping, c.wallJumping:
+		// The air attack ends in the jump it was done in, it's not a new one
+		if c.currentState != c.airAttacking {
+			c.publish(Jumped)
+		}
+	case c.attacking, c.comboAttacking, c.airAttacking, c.pogoing:
+		c.publish(Attacked)
+	case c.hit:
+		c.publish(Hurt)
+	case c.dead:
+		c.publish(Died)
+	case c.showingAlarm:
+		c.publish(Alarmed)
+	case c.standing, c.walking:
+		switch c.currentState {
+		case c.falling, c.wallSliding, c.airAttacking, c.pogoing:
+			c.publish(Landed)
+		}
+	}
+}
+
+// TakeEvents returns the events published since the last call
+func (c *Character) TakeEvents() []Event {
+	events := c.events
+	c.events = nil
+	return events
+}
@@ -0,0 +1,42 @@
+package characters
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPublishTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to func(c *Character) characterState
+		want     []EventKind
+	}{
+		{"jump", func(c *Character) characterState { return c.standing }, func(c *Character) characterState { return c.jumping }, []EventKind{Jumped}},
+		{"double jump", func(c *Character) characterState { return c.jumping }, func(c *Character) characterState { return c.doubleJumping }, []EventKind{Jumped}},
+		{"air attack ends in the jump", func(c *Character) characterState { return c.airAttacking }, func(c *Character) characterState { return c.jumping }, nil},
+		{"pogo bounce", func(c *Character) characterState { return c.pogoing }, func(c *Character) characterState { return c.jumping }, []EventKind{Jumped}},
+		{"attack", func(c *Character) characterState { return c.standing }, func(c *Character) characterState { return c.attacking }, []EventKind{Attacked}},
+		{"air attack", func(c *Character) characterState { return c.jumping }, func(c *Character) characterState { return c.airAttacking }, []EventKind{Attacked}},
+		{"land from a fall", func(c *Character) characterState { return c.falling }, func(c *Character) characterState { return c.standing }, []EventKind{Landed}},
+		{"land from a wall slide", func(c *Character) characterState { return c.wallSliding }, func(c *Character) characterState { return c.walking }, []EventKind{Landed}},
+		{"land from an air attack", func(c *Character) characterState { return c.airAttacking }, func(c *Character) characterState { return c.walking }, []EventKind{Landed}},
+		{"land from a pogo", func(c *Character) characterState { return c.pogoing }, func(c *Character) characterState { return c.standing }, []EventKind{Landed}},
+		{"stop walking", func(c *Character) characterState { return c.walking }, func(c *Character) characterState { return c.standing }, nil},
+		{"same state", func(c *Character) characterState { return c.jumping }, func(c *Character) characterState { return c.jumping }, nil},
+		{"hurt", func(c *Character) characterState { return c.walking }, func(c *Character) characterState { return c.hit }, []EventKind{Hurt}},
+		{"die", func(c *Character) characterState { return c.hit }, func(c *Character) characterState { return c.dead }, []EventKind{Died}},
+	}
+	for _, tt := range tests {
+		c := NewPlayerCharacter(0, 0, nil, nil, nil)
+		c.TakeEvents()
+		c.currentState = tt.from(c)
+		c.publishTransition(tt.to(c))
+		var got []EventKind
+		for _, e := range c.TakeEvents() {
+			got = append(got, e.Kind)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: got events %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
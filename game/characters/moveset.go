@@ -0,0 +1,402 @@
+package characters
+
+import (
+	"simpleplatformer/constants"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// comboState is a hit of the ground combo, attacking again during the hit chains the next one
+type comboState struct {
+	character *Character
+}
+
+func (s *comboState) move(float32) {}
+
+func (s *comboState) jump() {}
+
+// attack buffers the next hit of the combo, it starts as soon as the current one ends
+func (s *comboState) attack() {
+	c := s.character
+	if c.comboStep < constants.ComboLength {
+		c.comboBuffered = true
+	}
+}
+
+func (s *comboState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *comboState) kill(float32) {
+	s.character.setState(s.character.dead)
+}
+
+func (s *comboState) showAlarm() {}
+
+func (s *comboState) climb(float32, []*ladders.Ladder) {}
+
+func (s *comboState) fly(float32, float32) {}
+
+func (s *comboState) swim() {}
+
+func (s *comboState) dropDown([]*platforms.Platform) {}
+
+func (s *comboState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	// Every hit lunges forward a bit
+	c.vx *= constants.ComboLungeFriction
+	if !c.stickToGround(platforms) {
+		c.setState(c.falling)
+		return
+	}
+	if c.time <= constants.ComboHitLength {
+		return
+	}
+	if c.comboBuffered && c.CanAttack() {
+		comboHit(c, c.comboStep+1)
+		return
+	}
+	c.resetVX()
+	c.setState(c.standing)
+}
+
+func (s *comboState) getAnimationRects() []*sdl.Rect {
+	return weapons[s.character.weapon].attackRects
+}
+
+func (s *comboState) String() string {
+	return "comboState"
+}
+
+// comboHit swings the weapon for the given step of the combo, the last one hits harder
+func comboHit(c *Character, step int) {
+	c.comboStep = step
+	c.comboBuffered = false
+	c.stamina -= c.attackCost()
+	p := c.newProjectile(c)
+	if step == constants.ComboLength {
+		p.damage.Amount = constants.ComboFinisherDamage
+		p.damage.KnockbackVX = constants.ComboFinisherKnockbackVX
+	}
+	c.projectiles = append(c.projectiles, p)
+	c.vx = constants.ComboLungeVX
+	if !c.facedRight {
+		c.vx = -c.vx
+	}
+	if c.currentState == c.comboAttacking {
+		// Staying in the same state publishes nothing
+		c.time = 0
+		c.publish(Attacked)
+		return
+	}
+	c.setState(c.comboAttacking)
+}
+
+// airAttackingState is a slash done while jumping or falling, the character keeps flying meanwhile
+type airAttackingState struct {
+	character *Character
+}
+
+func (s *airAttackingState) move(newVX float32) {
+	setVelocityAndSwitchFacedRight(s.character, newVX)
+}
+
+func (s *airAttackingState) jump() {}
+
+func (s *airAttackingState) attack() {}
+
+func (s *airAttackingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *airAttackingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *airAttackingState) showAlarm() {}
+
+func (s *airAttackingState) climb(float32, []*ladders.Ladder) {}
+
+func (s *airAttackingState) fly(float32, float32) {}
+
+func (s *airAttackingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *airAttackingState) dropDown([]*platforms.Platform) {}
+
+func (s *airAttackingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	c.applyGravity()
+	if conditionalLand(c, platforms) {
+		return
+	}
+	if c.time > constants.AirSlashLength {
+		if c.isFalling() {
+			c.setState(c.falling)
+		} else {
+			c.setState(c.jumping)
+		}
+	}
+}
+
+func (s *airAttackingState) getAnimationRects() []*sdl.Rect {
+	return weapons[s.character.weapon].attackRects
+}
+
+func (s *airAttackingState) String() string {
+	return "airAttackingState"
+}
+
+func conditionalSwitchToAirAttackingState(c *Character) {
+	// Only the player can attack in the air
+	if c.airAttacking == nil || !c.CanAttack() {
+		return
+	}
+	c.stamina -= c.attackCost()
+	c.projectiles = append(c.projectiles, c.newProjectile(c))
+	c.setState(c.airAttacking)
+}
+
+// pogoingState thrusts the weapon downwards while falling, the character bounces off the enemies it hits
+type pogoingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+func (s *pogoingState) move(newVX float32) {
+	setVelocityAndSwitchFacedRight(s.character, newVX)
+}
+
+func (s *pogoingState) jump() {}
+
+func (s *pogoingState) attack() {}
+
+func (s *pogoingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *pogoingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *pogoingState) showAlarm() {}
+
+func (s *pogoingState) climb(float32, []*ladders.Ladder) {}
+
+func (s *pogoingState) fly(float32, float32) {}
+
+func (s *pogoingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *pogoingState) dropDown([]*platforms.Platform) {}
+
+func (s *pogoingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	if c.vy < constants.PogoVY {
+		// The thrust dives faster than a fall
+		c.vy += physics.FallGravity * 2
+	}
+	conditionalLand(c, platforms)
+}
+
+func (s *pogoingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *pogoingState) String() string {
+	return "pogoingState"
+}
+
+// bounce throws the pogoing character up after its thrust hit something
+func (c *Character) bounce() {
+	if c.currentState != c.pogoing {
+		return
+	}
+	c.vy = -constants.PogoBounceVY
+	c.stamina = constants.CharacterStaminaMax
+	c.setState(c.jumping)
+}
+
+// blockingState keeps the guard up, hits from the front are weakened and the ones
+// coming right after raising the guard are parried
+type blockingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+// move only turns the guard around, the character keeps sliding if it was pushed back
+func (s *blockingState) move(newVX float32) {
+	c := s.character
+	vx := c.vx
+	setVelocityAndSwitchFacedRight(c, newVX)
+	c.vx = vx
+}
+
+func (s *blockingState) jump() {}
+
+func (s *blockingState) attack() {}
+
+func (s *blockingState) hit(d Damage) {
+	c := s.character
+	fromFront := d.KnockbackVX != 0 && (d.KnockbackVX < 0) == c.facedRight
+	if !fromFront || d.Type == Spikes || d.Type == Drowning {
+		prepareAndSetHitState(c, d)
+		return
+	}
+	if c.time <= constants.ParryWindow {
+		c.parry()
+		return
+	}
+	c.publish(Blocked)
+	// Arrows just stick in the guard
+	if d.Type == Pierce {
+		return
+	}
+	// Blocked hits deal half the damage and push back less
+	d.Amount /= 2
+	d.KnockbackVX /= 2
+	// Light hits do no harm, the guard only gets pushed back
+	if d.Amount == 0 {
+		c.vx = d.KnockbackVX
+		return
+	}
+	prepareAndSetHitState(c, d)
+}
+
+func (s *blockingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *blockingState) showAlarm() {}
+
+func (s *blockingState) climb(float32, []*ladders.Ladder) {}
+
+func (s *blockingState) fly(float32, float32) {}
+
+func (s *blockingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *blockingState) dropDown([]*platforms.Platform) {}
+
+func (s *blockingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	c.vx *= constants.BlockPushbackFriction
+	if !c.stickToGround(platforms) {
+		c.setState(c.falling)
+	}
+}
+
+func (s *blockingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *blockingState) String() string {
+	return "blockingState"
+}
+
+// parry negates the hit and staggers the attacker if it was swinging its weapon
+func (c *Character) parry() {
+	c.publish(Parried)
+	a := c.attacker
+	if a == nil || a.currentState != a.attacking {
+		return
+	}
+	stagger := Damage{0, Slash, constants.ParryKnockbackVX, constants.CharacterVYWhenHit}
+	a.currentState.hit(stagger.Towards(float32(a.X - c.X)))
+}
+
+// chargingState builds up a heavy attack, it is released when the attack button is
+type chargingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+func (s *chargingState) move(newVX float32) {
+	setVelocityAndSwitchFacedRight(s.character, newVX)
+	s.character.vx = 0
+}
+
+func (s *chargingState) jump() {}
+
+func (s *chargingState) attack() {}
+
+func (s *chargingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *chargingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *chargingState) showAlarm() {}
+
+func (s *chargingState) climb(float32, []*ladders.Ladder) {}
+
+func (s *chargingState) fly(float32, float32) {}
+
+func (s *chargingState) swim() {
+	conditionalSwim(s.character)
+}
+
+func (s *chargingState) dropDown([]*platforms.Platform) {}
+
+func (s *chargingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	if !c.stickToGround(platforms) {
+		c.setState(c.falling)
+	}
+}
+
+func (s *chargingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *chargingState) String() string {
+	return "chargingState"
+}
+
+// IsCharged returns true if the heavy attack is charged and will be done when released
+func (c *Character) IsCharged() bool {
+	return c.currentState == c.charging && c.time >= constants.ChargeTime
+}
+
+// heavyAttack releases the charged attack, it uses up all the stamina
+func heavyAttack(c *Character) {
+	p := c.newProjectile(c)
+	p.damage.Amount = constants.HeavyDamage
+	p.damage.KnockbackVX = constants.HeavyKnockbackVX
+	p.piercing = true
+	c.projectiles = append(c.projectiles, p)
+	c.stamina = 0
+	c.setState(c.attacking)
+}
+
+// conditionalLand puts the character flying down onto the platform it touches, returns true if it landed
+func conditionalLand(c *Character, platforms []*platforms.Platform) bool {
+	if !c.isFalling() {
+		return false
+	}
+	for _, p := range platforms {
+		if c.isTouchingPlatformFromAbove(p) {
+			c.Y = p.SurfaceY(c.X) - c.H
+			c.vy = 0
+			if c.vx == 0 {
+				c.setState(c.standing)
+			} else {
+				c.setState(c.walking)
+			}
+			return true
+		}
+	}
+	return false
+}
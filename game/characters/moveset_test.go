@@ -0,0 +1,47 @@
+package characters
+
+import (
+	"simpleplatformer/constants"
+	"testing"
+)
+
+// blockingPlayer returns the player holding the guard up facing right, past the parry window
+func blockingPlayer() *Character {
+	c := NewPlayerCharacter(0, 0, nil, nil, nil)
+	c.facedRight = true
+	c.currentState = c.blocking
+	c.time = constants.ParryWindow + 1
+	c.TakeEvents()
+	return c
+}
+
+func TestBlockLightHit(t *testing.T) {
+	c := blockingPlayer()
+	health := c.health
+	c.currentState.hit(Damage{1, Slash, -2, constants.CharacterVYWhenHit})
+	if c.currentState != c.blocking {
+		t.Errorf("got %v, want the guard kept up", c.currentState)
+	}
+	if c.health != health {
+		t.Errorf("got health %v, want %v", c.health, health)
+	}
+	if c.vx != -1 {
+		t.Errorf("got pushback %v, want -1", c.vx)
+	}
+	events := c.TakeEvents()
+	if len(events) != 1 || events[0].Kind != Blocked {
+		t.Errorf("got events %v, want only Blocked", events)
+	}
+}
+
+func TestBlockHeavyHit(t *testing.T) {
+	c := blockingPlayer()
+	health := c.health
+	c.currentState.hit(Damage{3, Slash, -2, constants.CharacterVYWhenHit})
+	if c.currentState != c.hit {
+		t.Errorf("got %v, want the hit state", c.currentState)
+	}
+	if c.health != health-1 {
+		t.Errorf("got health %v, want %v", c.health, health-1)
+	}
+}
@@ -0,0 +1,94 @@
+package characters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"simpleplatformer/constants"
+)
+
+// Physics tunes how the characters jump and fall, speeds are given in pixels per frame and times in frames
+type Physics struct {
+	JumpSpeed float32 `json:"jumpSpeed"`
+	// RiseGravity pulls the character down while it goes up, FallGravity while it falls
+	RiseGravity  float32 `json:"riseGravity"`
+	FallGravity  float32 `json:"fallGravity"`
+	MaxFallSpeed float32 `json:"maxFallSpeed"`
+	// JumpCutSpeed is the speed a jump is slowed down to when the jump button is released early
+	JumpCutSpeed float32 `json:"jumpCutSpeed"`
+	// CoyoteTime is how long after walking off an edge the character can still jump
+	CoyoteTime int `json:"coyoteTime"`
+	// JumpBufferTime is how long a jump pressed in the air is remembered, it's done on landing
+	JumpBufferTime int `json:"jumpBufferTime"`
+}
+
+// DefaultPhysics returns the physics used when there is no config
+func DefaultPhysics() Physics {
+	return Physics{
+		JumpSpeed:      constants.JumpSpeed,
+		RiseGravity:    constants.Gravity,
+		FallGravity:    constants.FallGravity,
+		MaxFallSpeed:   constants.CharacterVYMax,
+		JumpCutSpeed:   constants.JumpCutSpeed,
+		CoyoteTime:     constants.CoyoteTime,
+		JumpBufferTime: constants.JumpBufferTime,
+	}
+}
+
+// physics is used by all the characters
+var physics = DefaultPhysics()
+
+// LoadPhysics reads the physics config, values missing in the file are taken from DefaultPhysics
+func LoadPhysics(path string) (Physics, error) {
+	p := DefaultPhysics()
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(content, &p); err != nil {
+		return p, fmt.Errorf("could not parse physics config %v: %v", path, err)
+	}
+	return p, nil
+}
+
+// SetPhysics makes all the characters move with the given physics
+func SetPhysics(p Physics) {
+	physics = p
+}
+
+// applyGravity pulls the character down, less while it still goes up
+func (c *Character) applyGravity() {
+	if c.vy < 0 {
+		c.vy += physics.RiseGravity
+	} else if c.vy < physics.MaxFallSpeed {
+		c.vy += physics.FallGravity
+	}
+}
+
+// startJump makes the character jump off the ground, the jump gets lower if the button is released early
+func startJump(c *Character) {
+	c.vy = -physics.JumpSpeed
+	c.setState(c.jumping)
+	c.variableJump = true
+	c.coyoteTime = 0
+	c.jumpBuffer = 0
+}
+
+// updateJumpTimers counts down the coyote time and does the buffered jump once the character can
+func (c *Character) updateJumpTimers() {
+	if c.coyoteTime > 0 {
+		c.coyoteTime--
+	}
+	if c.jumpBuffer > 0 {
+		c.jumpBuffer--
+		if c.currentState == c.standing || c.currentState == c.walking {
+			c.currentState.jump()
+		}
+	}
+}
+
+// walkOffEdge starts falling from the ground, the character can still jump for a moment
+func walkOffEdge(c *Character) {
+	c.coyoteTime = physics.CoyoteTime
+	c.setState(c.falling)
+}
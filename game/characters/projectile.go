@@ -0,0 +1,203 @@
+package characters
+
+import (
+	"log"
+	"math"
+	"simpleplatformer/common"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/platforms"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+type faction int
+
+const (
+	playerFaction faction = iota
+	enemyFaction
+)
+
+func updateProjectiles(prs []*projectile, platforms []*platforms.Platform) []*projectile {
+	result := []*projectile{}
+	for _, pr := range prs {
+		if pr.destroyed == false {
+			pr.update(platforms)
+			result = append(result, pr)
+		}
+	}
+	return result
+}
+
+// projectile is anything that flies and hurts characters of the other faction
+type projectile struct {
+	time       int
+	lifetime   int
+	texture    *sdl.Texture
+	rects      []*sdl.Rect
+	x          float32
+	y          float32
+	w          int32
+	h          int32
+	vx         float32
+	vy         float32
+	gravity    float32
+	piercing   bool
+	solid      bool // collides with platforms
+	rotating   bool // rotates along the flight direction
+	faction    faction
+	owner      *Character
+	damage     Damage
+	facedRight bool
+	destroyed  bool
+	alreadyHit []*Character
+	// below sticks the projectile under its owner for as long as it keeps pogoing
+	below bool
+}
+
+func newArrowForCharacter(c *Character) *projectile {
+	rects := newProjectileAnimationRects([]common.RelativeRectPosition{
+		{0, 0},
+		{1, 0},
+	})
+	x := float32(c.X)
+	y := float32(c.Y)
+	vx, vy := arcingVelocity(x, y, float32(c.aimX), float32(c.aimY), constants.ArrowVX, constants.ArrowGravity)
+	return &projectile{
+		time:       0,
+		lifetime:   constants.ArrowLifetime,
+		texture:    c.projectileTexture,
+		rects:      rects,
+		x:          x,
+		y:          y,
+		w:          constants.ProjectileDestWidth,
+		h:          constants.ProjectileDestHeight,
+		vx:         vx,
+		vy:         vy,
+		gravity:    constants.ArrowGravity,
+		piercing:   false,
+		solid:      true,
+		rotating:   true,
+		faction:    c.faction,
+		owner:      c,
+		damage:     arrowDamage(),
+		facedRight: vx > 0,
+		destroyed:  false,
+		alreadyHit: []*Character{},
+	}
+}
+
+// arcingVelocity returns the launch velocity for which the projectile
+// flying with given horizontal speed lands at the target
+func arcingVelocity(x, y, targetX, targetY, speedX, gravity float32) (float32, float32) {
+	dx := targetX - x
+	dy := targetY - y
+	vx := speedX
+	if dx < 0 {
+		vx = -speedX
+	}
+	flightTime := dx / vx
+	if flightTime < 1 {
+		flightTime = 1
+	}
+	vy := dy/flightTime - gravity*flightTime/2
+	return vx, vy
+}
+
+func newProjectileAnimationRects(positions []common.RelativeRectPosition) []*sdl.Rect {
+	arr := []*sdl.Rect{}
+	for _, p := range positions {
+		r := sdl.Rect{
+			X: int32(p.XIndex) * constants.ProjectileSourceWidth,
+			Y: int32(p.YIndex) * constants.ProjectileSourceHeight,
+			W: constants.ProjectileSourceWidth,
+			H: constants.ProjectileSourceHeight,
+		}
+		arr = append(arr, &r)
+	}
+	return arr
+}
+
+func (p *projectile) update(platforms []*platforms.Platform) {
+	p.time++
+	if p.below {
+		p.x, p.y = float32(p.owner.X), float32(p.owner.Y+p.owner.H/2+p.h/4)
+		p.destroyed = p.owner.currentState != p.owner.pogoing
+		return
+	}
+	p.vy += p.gravity
+	p.x += p.vx
+	p.y += p.vy
+	if p.time > p.lifetime {
+		p.destroyed = true
+	}
+	if !p.solid {
+		return
+	}
+	for _, pl := range platforms {
+		if int32(p.x) > pl.X-pl.W/2 && int32(p.x) < pl.X+pl.W/2 && int32(p.y) > pl.Y-pl.H/2 && int32(p.y) < pl.Y+pl.H/2 {
+			p.destroyed = true
+			return
+		}
+	}
+}
+
+// hitCharacters hits every character of the other faction touched by the projectile
+func (p *projectile) hitCharacters(characters []*Character) {
+	for _, e := range characters {
+		if e.faction == p.faction || e.IsDead() || p.hasAlreadyHit(e) {
+			continue
+		}
+		x, y := int32(p.x), int32(p.y)
+		if (y+p.h/2) > (e.Y-e.H/2) && (y-p.h/2) < (e.Y+e.H/2) { // Touches enemy vertically
+			if (x+p.w/2) > (e.X-e.W/2) && (x-p.w/2) < (e.X+e.W/2) { // Touches enemy horizontally
+				e.hitBy(p.owner, p.damage.Towards(p.vx))
+				p.alreadyHit = append(p.alreadyHit, e)
+				if p.below {
+					p.owner.bounce()
+				}
+				if !p.piercing {
+					p.destroyed = true
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *projectile) hasAlreadyHit(c *Character) bool {
+	for _, e := range p.alreadyHit {
+		if e == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *projectile) hitBox() sdl.Rect {
+	return sdl.Rect{int32(p.x) - p.w/2, int32(p.y) - p.h/2, p.w, p.h}
+}
+
+func (p *projectile) shift(dx, dy int32) {
+	p.x += float32(dx)
+	p.y += float32(dy)
+}
+
+func (p *projectile) draw(r *sdl.Renderer) {
+	displayedFrame := p.time / 10 % len(p.rects)
+	src := p.rects[displayedFrame]
+	dst := &sdl.Rect{int32(p.x) - p.w/2, int32(p.y) - p.h/2, p.w, p.h}
+	var flip sdl.RendererFlip
+	var angle float64
+	if p.rotating {
+		flip = sdl.FLIP_NONE
+		angle = math.Atan2(float64(p.vy), float64(p.vx)) * 180 / math.Pi
+	} else if p.facedRight {
+		flip = sdl.FLIP_NONE
+	} else {
+		flip = sdl.FLIP_HORIZONTAL
+	}
+	err := r.CopyEx(p.texture, src, dst, angle, nil, flip)
+	if err != nil {
+		log.Fatalf("could not copy projectile texture: %v", err)
+	}
+}
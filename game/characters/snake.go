@@ -3,6 +3,7 @@ package characters
 import (
 	"simpleplatformer/common"
 	"simpleplatformer/constants"
+	"simpleplatformer/game/platforms"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
@@ -27,8 +28,9 @@ func NewSnake(x, y int32, characterTexture *sdl.Texture) *Character {
 		stamina:       constants.CharacterStaminaMax,
 		time:          0,
 		facedRight:    true,
-		swooshes:      []*swoosh{},
+		projectiles:   []*projectile{},
 		characterType: enemySnake,
+		faction:       enemyFaction,
 	}
 	standingSnakeState := standingState{
 		character:      &c,
@@ -50,7 +52,7 @@ func NewSnake(x, y int32, characterTexture *sdl.Texture) *Character {
 		character:      &c,
 		animationRects: standingSnakeRects,
 	}
-	c.updateAttack = func(enemies []*Character) {
+	c.updateAttack = func(_ []*platforms.Platform, enemies []*Character) {
 		updateTouchAttack(&c, enemies)
 	}
 	c.standing = &standingSnakeState
@@ -64,14 +66,20 @@ func NewSnake(x, y int32, characterTexture *sdl.Texture) *Character {
 }
 
 func updateTouchAttack(snake *Character, enemies []*Character) {
+	if snake.IsDead() {
+		return
+	}
 	s := *snake
 	for _, e := range enemies {
-		if snake == e || e.IsEnemySnake() {
+		if snake == e || e.faction == snake.faction {
 			continue
 		}
 		if (s.Y+s.H/2) > (e.Y-e.H/2) && (s.Y-s.H/2) < (e.Y+e.H/2) { // Touches enemy vertically
 			if (s.X+s.W/2) > (e.X-e.W/2) && (s.X-s.W/2) < (e.X+e.W/2) { // Touches enemy horizontally
-				e.Hit(s.vx - e.vx)
+				// Snakes have no attacking state, Attacked is published when the bite lands to play the hiss
+				if e.hitBy(snake, biteDamage().Towards(float32(e.X-s.X))) {
+					snake.publish(Attacked)
+				}
 				break
 			}
 		}
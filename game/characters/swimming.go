@@ -0,0 +1,111 @@
+package characters
+
+import (
+	"simpleplatformer/constants"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// swimmingState makes the character move slowly and sink gently, jumping swims up
+type swimmingState struct {
+	character      *Character
+	animationRects []*sdl.Rect
+}
+
+func (s *swimmingState) move(newVX float32) {
+	setVelocityAndSwitchFacedRight(s.character, newVX*constants.SwimVXFactor)
+}
+
+func (s *swimmingState) jump() {
+	c := s.character
+	// Jumping at the surface gets the character out of the water
+	if c.Y-c.H/2 <= c.waterSurfaceY {
+		c.vy = -physics.JumpSpeed
+		c.setState(c.jumping)
+		return
+	}
+	if c.time > constants.SwimStrokeInterval {
+		c.time = 0
+		c.vy = -constants.SwimStrokeVY
+	}
+}
+
+func (s *swimmingState) attack() {}
+
+func (s *swimmingState) hit(d Damage) {
+	prepareAndSetHitState(s.character, d)
+}
+
+func (s *swimmingState) kill(newVX float32) {
+	setVelocityAndSwitchToDeadState(s.character, newVX)
+}
+
+func (s *swimmingState) showAlarm() {}
+
+func (s *swimmingState) climb(newVY float32, lads []*ladders.Ladder) {
+	conditionalClimbLadder(s.character, newVY, lads)
+}
+
+func (s *swimmingState) fly(float32, float32) {}
+
+func (s *swimmingState) swim() {}
+
+func (s *swimmingState) dropDown([]*platforms.Platform) {}
+
+func (s *swimmingState) update(platforms []*platforms.Platform, _ []*ladders.Ladder) {
+	c := s.character
+	c.time++
+	if !c.inWater {
+		c.setState(c.falling)
+		return
+	}
+	c.vy += constants.WaterGravity
+	if c.vy > constants.WaterVYMax {
+		c.vy = constants.WaterVYMax
+	}
+	// Float at the surface instead of jumping out of the water
+	if c.vy < 0 && c.Y-c.H/2 < c.waterSurfaceY {
+		c.vy = 0
+	}
+	for _, p := range platforms {
+		if c.vy >= 0 && c.isTouchingPlatformFromAbove(p) {
+			c.Y = p.SurfaceY(c.X) - c.H
+			c.vy = 0
+		}
+	}
+}
+
+func (s *swimmingState) getAnimationRects() []*sdl.Rect {
+	return s.animationRects
+}
+
+func (s *swimmingState) String() string {
+	return "swimmingState"
+}
+
+// updateBreath makes the character lose breath under the water and get hurt when it runs out of it
+func (c *Character) updateBreath() {
+	if c.inWater && c.Y-c.H/2 > c.waterSurfaceY {
+		c.timeUnderwater++
+		drowningTime := c.timeUnderwater - constants.BreathMax
+		if drowningTime > 0 && drowningTime%constants.DrownDamageInterval == 0 {
+			c.Hit(drowningDamage())
+		}
+	} else {
+		c.timeUnderwater -= constants.BreathRefillRate
+		if c.timeUnderwater < 0 {
+			c.timeUnderwater = 0
+		}
+	}
+	c.inWater = false
+}
+
+// Breath returns the number of frames the character can still stay under the water
+func (c *Character) Breath() int {
+	if c.timeUnderwater > constants.BreathMax {
+		return 0
+	}
+	return constants.BreathMax - c.timeUnderwater
+}
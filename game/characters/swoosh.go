@@ -1,37 +1,12 @@
 package characters
 
 import (
-	"log"
 	"simpleplatformer/common"
 	"simpleplatformer/constants"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
 
-func updateSwooshes(sws []*swoosh) []*swoosh {
-	result := []*swoosh{}
-	for _, sw := range sws {
-		if sw.destroyed == false {
-			sw.update()
-			result = append(result, sw)
-		}
-	}
-	return result
-}
-
-type swoosh struct {
-	time       int
-	texture    *sdl.Texture
-	rects      []*sdl.Rect
-	x          int32
-	y          int32
-	w          int32
-	h          int32
-	vx         float32
-	facedRight bool
-	destroyed  bool
-}
-
 func moveAllRectsByX(rects []*sdl.Rect, shiftX int32) []*sdl.Rect {
 	results := []*sdl.Rect{}
 	for _, r := range rects {
@@ -41,15 +16,31 @@ func moveAllRectsByX(rects []*sdl.Rect, shiftX int32) []*sdl.Rect {
 	return results
 }
 
-func newSwooshForCharacter(c *Character) *swoosh {
+func newSwooshForCharacter(c *Character) *projectile {
 	posX := c.X - constants.SwooshXShift
 	if c.facedRight {
 		posX = c.X + constants.SwooshXShift
 	}
-	return newSwoosh(c.swooshTexture, posX, c.Y, c.facedRight)
+	p := newSwoosh(c.swooshTexture, posX, c.Y, c.facedRight, c.faction)
+	p.owner = c
+	p.damage = swooshDamage(c)
+	return p
+}
+
+// newPogoThrust creates a swoosh pointing down under the character, it lasts as long as the character pogoes
+func newPogoThrust(c *Character) *projectile {
+	p := newSwoosh(c.swooshTexture, c.X, c.Y+c.H/2, c.facedRight, c.faction)
+	p.owner = c
+	p.damage = swooshDamage(c)
+	p.damage.KnockbackVX = 0
+	p.vx, p.vy = 0, 1
+	p.rotating = true
+	p.below = true
+	return p
 }
 
-func newSwoosh(tex *sdl.Texture, x, y int32, facedRight bool) *swoosh {
+// newSwoosh creates a short-living projectile flying horizontally, not stopped by platforms
+func newSwoosh(tex *sdl.Texture, x, y int32, facedRight bool, f faction) *projectile {
 	rects := newCharacterAnimationRects([]common.RelativeRectPosition{
 		{0, 0},
 		{1, 0},
@@ -61,40 +52,24 @@ func newSwoosh(tex *sdl.Texture, x, y int32, facedRight bool) *swoosh {
 	if facedRight {
 		vx = constants.SwooshVX
 	}
-	return &swoosh{
+	return &projectile{
 		time:       0,
+		lifetime:   len(rects) * 10,
 		texture:    tex,
-		x:          x,
-		y:          y,
+		x:          float32(x),
+		y:          float32(y),
 		w:          constants.CharacterDestWidth,
 		h:          constants.CharacterDestHeight,
 		vx:         vx,
+		vy:         0,
+		gravity:    0,
+		piercing:   false,
+		solid:      false,
+		rotating:   false,
 		rects:      rects,
+		faction:    f,
 		facedRight: facedRight,
 		destroyed:  false,
-	}
-}
-
-func (s *swoosh) update() {
-	s.time++
-	s.x += int32(s.vx)
-	if s.time > len(s.rects)*10 {
-		s.destroyed = true
-	}
-}
-
-func (s *swoosh) draw(r *sdl.Renderer) {
-	displayedFrame := s.time / 10 % len(s.rects)
-	src := s.rects[displayedFrame]
-	dst := &sdl.Rect{s.x - s.w/2, s.y - s.h/2, s.w, s.h}
-	var flip sdl.RendererFlip
-	if s.facedRight {
-		flip = sdl.FLIP_NONE
-	} else {
-		flip = sdl.FLIP_HORIZONTAL
-	}
-	err := r.CopyEx(s.texture, src, dst, 0, nil, flip)
-	if err != nil {
-		log.Fatalf("could not copy Swoosh texture: %v", err)
+		alreadyHit: []*Character{},
 	}
 }
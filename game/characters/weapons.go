@@ -0,0 +1,151 @@
+package characters
+
+import (
+	"fmt"
+	"simpleplatformer/common"
+	"simpleplatformer/constants"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Weapon is what the player attacks with
+type Weapon int
+
+const (
+	Sword Weapon = iota
+	Spear
+	ThrowingKnives
+)
+
+var weaponNames = []string{"sword", "spear", "knives"}
+
+// ParseWeapon returns the weapon of the given name
+func ParseWeapon(name string) (Weapon, error) {
+	for i, n := range weaponNames {
+		if n == name {
+			return Weapon(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weapon: %v", name)
+}
+
+// String returns the name of the weapon, it's used in the save files
+func (w Weapon) String() string {
+	return weaponNames[w]
+}
+
+// weaponStats describe how the weapon is used
+type weaponStats struct {
+	staminaCost   int
+	newProjectile func(*Character) *projectile
+	// attackRects are the frames of the player attacking with the weapon
+	attackRects []*sdl.Rect
+}
+
+var weapons = []weaponStats{
+	Sword: {
+		staminaCost:   constants.SwordStaminaCost,
+		newProjectile: newSwooshForCharacter,
+		attackRects: newCharacterAnimationRects([]common.RelativeRectPosition{
+			{12, 1},
+			{11, 1},
+			{12, 1},
+			{13, 1},
+		}),
+	},
+	Spear: {
+		staminaCost:   constants.SpearStaminaCost,
+		newProjectile: newSpearThrust,
+		attackRects: newCharacterAnimationRects([]common.RelativeRectPosition{
+			{11, 1},
+			{13, 1},
+		}),
+	},
+	ThrowingKnives: {
+		staminaCost:   constants.KnifeStaminaCost,
+		newProjectile: newThrowingKnife,
+		attackRects: newCharacterAnimationRects([]common.RelativeRectPosition{
+			{12, 1},
+			{13, 1},
+		}),
+	},
+}
+
+// Equip makes the player attack with the weapon
+func (c *Character) Equip(w Weapon) {
+	c.weapon = w
+	c.newProjectile = weapons[w].newProjectile
+}
+
+// Weapon returns the weapon the character attacks with
+func (c *Character) Weapon() Weapon {
+	return c.weapon
+}
+
+// attackCost returns the stamina needed for an attack, enemies need all of it
+func (c *Character) attackCost() int {
+	if !c.IsPlayer() {
+		return constants.CharacterStaminaMax
+	}
+	return weapons[c.weapon].staminaCost
+}
+
+// newSpearThrust creates a short and fast thrust reaching further than a swoosh, it pierces through enemies
+func newSpearThrust(c *Character) *projectile {
+	x, vx := c.X+constants.SpearReach, constants.SpearVX
+	if !c.facedRight {
+		x, vx = c.X-constants.SpearReach, -vx
+	}
+	return &projectile{
+		time:       0,
+		lifetime:   constants.SpearLifetime,
+		texture:    c.projectileTexture,
+		rects:      newProjectileAnimationRects([]common.RelativeRectPosition{{2, 1}, {3, 1}}),
+		x:          float32(x),
+		y:          float32(c.Y),
+		w:          constants.ProjectileDestWidth,
+		h:          constants.ProjectileDestHeight,
+		vx:         vx,
+		vy:         0,
+		gravity:    0,
+		piercing:   true,
+		solid:      false,
+		rotating:   false,
+		faction:    c.faction,
+		owner:      c,
+		damage:     Damage{constants.SpearDamage, Pierce, constants.SpearKnockbackVX, constants.CharacterVYWhenHit},
+		facedRight: c.facedRight,
+		destroyed:  false,
+		alreadyHit: []*Character{},
+	}
+}
+
+// newThrowingKnife creates a spinning knife flying far, it's stopped by the platforms
+func newThrowingKnife(c *Character) *projectile {
+	vx := constants.KnifeVX
+	if !c.facedRight {
+		vx = -vx
+	}
+	return &projectile{
+		time:       0,
+		lifetime:   constants.KnifeLifetime,
+		texture:    c.projectileTexture,
+		rects:      newProjectileAnimationRects([]common.RelativeRectPosition{{0, 1}, {1, 1}}),
+		x:          float32(c.X),
+		y:          float32(c.Y),
+		w:          constants.ProjectileDestWidth,
+		h:          constants.ProjectileDestHeight,
+		vx:         vx,
+		vy:         0,
+		gravity:    constants.KnifeGravity,
+		piercing:   false,
+		solid:      true,
+		rotating:   false,
+		faction:    c.faction,
+		owner:      c,
+		damage:     Damage{constants.KnifeDamage, Pierce, constants.KnifeKnockbackVX, constants.CharacterVYWhenHit},
+		facedRight: c.facedRight,
+		destroyed:  false,
+		alreadyHit: []*Character{},
+	}
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/characters"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// aiDebugInfo describes what the AI controller is currently up to
+type aiDebugInfo struct {
+	character    *characters.Character
+	state        string
+	role         string
+	startX       int32
+	destinationX int32
+	cooldownTime int
+}
+
+type debugOverlay struct {
+	enabled bool
+}
+
+func (o *debugOverlay) toggle() {
+	o.enabled = !o.enabled
+}
+
+func (o *debugOverlay) draw(r *sdl.Renderer, g *Game) {
+	if !o.enabled {
+		return
+	}
+	f := openFont(12)
+	defer f.Close()
+	red, green, blue, alpha, err := r.GetDrawColor()
+	if err != nil {
+		log.Fatalf("could not get draw color: %v", err)
+	}
+	defer r.SetDrawColor(red, green, blue, alpha)
+
+	// Collision boxes
+	r.SetDrawColor(255, 0, 255, 255)
+	for _, p := range g.activePlatforms {
+		r.DrawRect(&sdl.Rect{p.X - p.W/2, p.Y - p.H/2, p.W, p.H})
+	}
+	for _, l := range g.activeLadders {
+		r.DrawRect(&sdl.Rect{l.X - l.W/2, l.Y - l.H/2, l.W, l.H})
+	}
+	for _, c := range append(g.enemies, g.player) {
+		drawDebugHitBoxes(r, c)
+	}
+
+	for _, ctrl := range g.aiControllers {
+		info := ctrl.debugInfo()
+		c := info.character
+		if c.IsDead() {
+			continue
+		}
+		// Patrol bounds
+		r.SetDrawColor(255, 255, 0, 255)
+		left := info.startX - constants.AiPatrolDistance
+		right := info.startX + constants.AiPatrolDistance
+		r.DrawLine(left, c.Y+c.H, right, c.Y+c.H)
+		r.DrawLine(left, c.Y, left, c.Y+c.H)
+		r.DrawLine(right, c.Y, right, c.Y+c.H)
+		// Vision range
+		r.SetDrawColor(0, 255, 0, 255)
+		sight := &sdl.Rect{c.X, c.Y - constants.CharacterDestHeight, constants.CharacterSightLimit, 2 * constants.CharacterDestHeight}
+		if !c.IsFacedRight() {
+			sight.X -= constants.CharacterSightLimit
+		}
+		r.DrawRect(sight)
+		// Attack range
+		r.SetDrawColor(255, 0, 0, 255)
+		r.DrawRect(&sdl.Rect{c.X - constants.CharacterDestWidth/2, c.Y - c.H/2, constants.CharacterDestWidth, c.H})
+		// Planned path
+		r.SetDrawColor(0, 255, 255, 255)
+		r.DrawLine(c.X, c.Y, info.destinationX, c.Y)
+		r.DrawRect(&sdl.Rect{info.destinationX - 2, c.Y - 2, 4, 4})
+
+		label := fmt.Sprintf("%v %v cd:%v", info.state, info.role, info.cooldownTime)
+		err = drawText(r, f, label, c.X, c.Y-constants.CharacterDestHeight/2-14, sdl.Color{R: 255, G: 255, B: 255, A: 255})
+		if err != nil {
+			log.Fatalf("could not draw debug text: %v", err)
+		}
+	}
+}
+
+func drawDebugHitBoxes(r *sdl.Renderer, c *characters.Character) {
+	hitBox := c.HitBox()
+	r.DrawRect(&hitBox)
+	for _, p := range c.ProjectileHitBoxes() {
+		projectileHitBox := p
+		r.DrawRect(&projectileHitBox)
+	}
+}
@@ -0,0 +1,138 @@
+package game
+
+import (
+	"simpleplatformer/constants"
+	"simpleplatformer/game/characters"
+)
+
+// encounterCoordinator decides which enemies are allowed to engage the player.
+// Only holders of the attack tokens go for the player, the rest either flank
+// the player from the opposite side or hold their position at a safe distance.
+type encounterCoordinator struct {
+	members   []aiEnemyController
+	attackers []aiEnemyController
+	flankers  []aiEnemyController
+}
+
+func newEncounterCoordinator() *encounterCoordinator {
+	return &encounterCoordinator{
+		members:   []aiEnemyController{},
+		attackers: []aiEnemyController{},
+		flankers:  []aiEnemyController{},
+	}
+}
+
+func (ec *encounterCoordinator) register(ctrl aiEnemyController) {
+	ec.members = append(ec.members, ctrl)
+}
+
+// update gives back the tokens held by enemies that are dead
+func (ec *encounterCoordinator) update() {
+	ec.attackers = withoutDeadControllers(ec.attackers)
+	ec.flankers = withoutDeadControllers(ec.flankers)
+}
+
+func withoutDeadControllers(ctrls []aiEnemyController) []aiEnemyController {
+	result := []aiEnemyController{}
+	for _, ctrl := range ctrls {
+		if !ctrl.getCharacter().IsDead() {
+			result = append(result, ctrl)
+		}
+	}
+	return result
+}
+
+// requestAttackToken returns true if the enemy is allowed to attack the player
+func (ec *encounterCoordinator) requestAttackToken(ctrl aiEnemyController) bool {
+	if containsController(ec.attackers, ctrl) {
+		return true
+	}
+	if len(ec.attackers) >= constants.AiMaxAttackers {
+		return false
+	}
+	ec.flankers = removeController(ec.flankers, ctrl)
+	ec.attackers = append(ec.attackers, ctrl)
+	return true
+}
+
+// releaseTokens should be called when the enemy stops engaging the player
+func (ec *encounterCoordinator) releaseTokens(ctrl aiEnemyController) {
+	ec.attackers = removeController(ec.attackers, ctrl)
+	ec.flankers = removeController(ec.flankers, ctrl)
+}
+
+// waitingPosition returns x coordinate the enemy without the attack token should move to
+func (ec *encounterCoordinator) waitingPosition(ctrl aiEnemyController, playerCharacter *characters.Character) int32 {
+	c := ctrl.getCharacter()
+	if containsController(ec.flankers, ctrl) || len(ec.flankers) < constants.AiMaxFlankers {
+		if !containsController(ec.flankers, ctrl) {
+			ec.flankers = append(ec.flankers, ctrl)
+		}
+		return playerCharacter.X + ec.flankingSide(playerCharacter)*constants.AiWaitingDistance
+	}
+	if c.X < playerCharacter.X {
+		return playerCharacter.X - constants.AiWaitingDistance
+	}
+	return playerCharacter.X + constants.AiWaitingDistance
+}
+
+// flankingSide returns 1 if there are less attackers on the right side of the player, -1 otherwise
+func (ec *encounterCoordinator) flankingSide(playerCharacter *characters.Character) int32 {
+	attackersOnTheRight := 0
+	for _, a := range ec.attackers {
+		if a.getCharacter().X > playerCharacter.X {
+			attackersOnTheRight++
+		}
+	}
+	if attackersOnTheRight*2 < len(ec.attackers) {
+		return 1
+	}
+	return -1
+}
+
+// raiseAlarm alerts other enemies within earshot of the one that noticed the player
+func (ec *encounterCoordinator) raiseAlarm(source aiEnemyController) {
+	for _, m := range ec.members {
+		if m == source {
+			continue
+		}
+		if withinEarshot(m.getCharacter(), source.getCharacter()) {
+			m.alert()
+		}
+	}
+}
+
+func (ec *encounterCoordinator) roleOf(ctrl aiEnemyController) string {
+	if containsController(ec.attackers, ctrl) {
+		return "attacking"
+	}
+	if containsController(ec.flankers, ctrl) {
+		return "flanking"
+	}
+	return ""
+}
+
+func withinEarshot(c, otherCharacter *characters.Character) bool {
+	dx := c.X - otherCharacter.X
+	dy := c.Y - otherCharacter.Y
+	return dx*dx+dy*dy <= constants.AiEarshotDistance*constants.AiEarshotDistance
+}
+
+func containsController(ctrls []aiEnemyController, ctrl aiEnemyController) bool {
+	for _, c := range ctrls {
+		if c == ctrl {
+			return true
+		}
+	}
+	return false
+}
+
+func removeController(ctrls []aiEnemyController, ctrl aiEnemyController) []aiEnemyController {
+	result := []aiEnemyController{}
+	for _, c := range ctrls {
+		if c != ctrl {
+			result = append(result, c)
+		}
+	}
+	return result
+}
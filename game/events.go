@@ -0,0 +1,153 @@
+package game
+
+import (
+	"simpleplatformer/game/characters"
+	"simpleplatformer/game/pickups"
+)
+
+// Event is something that happened in the game, subscribers tell the events apart by their type
+type Event interface {
+	isEvent()
+}
+
+// CharacterHit is published when a character gets hurt but survives, Attacker is nil for hazards
+type CharacterHit struct {
+	Victim   *characters.Character
+	Attacker *characters.Character
+}
+
+// CharacterDied is published when a character dies, Killer is the last one who hit it or nil for hazards
+type CharacterDied struct {
+	Victim *characters.Character
+	Killer *characters.Character
+}
+
+type PlayerJumped struct {
+	Player *characters.Character
+}
+
+type EnemyJumped struct {
+	Enemy *characters.Character
+}
+
+// CharacterAttacked is published when a character swings a weapon or shoots, or when a snake bites
+type CharacterAttacked struct {
+	Character *characters.Character
+}
+
+type CharacterLanded struct {
+	Character *characters.Character
+}
+
+// LadderStepped is published regularly while a character climbs
+type LadderStepped struct {
+	Character *characters.Character
+}
+
+// EnemyAlarmed is published when an enemy notices the player
+type EnemyAlarmed struct {
+	Enemy *characters.Character
+}
+
+// AttackBlocked is published when a character blocks a hit with its guard, Parried if it was timed well
+type AttackBlocked struct {
+	Blocker  *characters.Character
+	Attacker *characters.Character
+	Parried  bool
+}
+
+// PickupCollected is published when the player collects a pickup, after its effect has been applied
+type PickupCollected struct {
+	Pickup *pickups.Pickup
+}
+
+// LevelCompleted is published once when the level is finished, right before Run returns
+type LevelCompleted struct {
+	Stats LevelStats
+}
+
+func (CharacterHit) isEvent()      {}
+func (CharacterDied) isEvent()     {}
+func (PlayerJumped) isEvent()      {}
+func (EnemyJumped) isEvent()       {}
+func (CharacterAttacked) isEvent() {}
+func (CharacterLanded) isEvent()   {}
+func (LadderStepped) isEvent()     {}
+func (EnemyAlarmed) isEvent()      {}
+func (AttackBlocked) isEvent()     {}
+func (PickupCollected) isEvent()   {}
+func (LevelCompleted) isEvent()    {}
+
+// Subscriber reacts to the game events
+type Subscriber func(Event)
+
+// eventBus queues the events published during a frame until they are dispatched
+type eventBus struct {
+	queue       []Event
+	subscribers []Subscriber
+}
+
+func (b *eventBus) subscribe(s Subscriber) {
+	b.subscribers = append(b.subscribers, s)
+}
+
+func (b *eventBus) publish(e Event) {
+	b.queue = append(b.queue, e)
+}
+
+// dispatch delivers the queued events in the order they were published, each one to the subscribers
+// in the order they subscribed. Events published by the subscribers are delivered in the same dispatch.
+func (b *eventBus) dispatch() {
+	for i := 0; i < len(b.queue); i++ {
+		for _, s := range b.subscribers {
+			s(b.queue[i])
+		}
+	}
+	b.queue = nil
+}
+
+// Subscribe lets s react to the game events, subscribers are called after the game ones
+func (g *Game) Subscribe(s Subscriber) {
+	g.events.subscribe(s)
+}
+
+// publishCharacterEvents takes the events published by the state transitions of the characters,
+// the player ones first and then the enemies in the level order
+func (g *Game) publishCharacterEvents() {
+	for _, c := range append([]*characters.Character{g.player}, g.enemies...) {
+		for _, e := range c.TakeEvents() {
+			switch e.Kind {
+			case characters.Jumped:
+				if c == g.player {
+					g.events.publish(PlayerJumped{c})
+				} else {
+					g.events.publish(EnemyJumped{c})
+				}
+			case characters.Attacked:
+				g.events.publish(CharacterAttacked{c})
+			case characters.Hurt:
+				g.events.publish(CharacterHit{c, e.Attacker})
+			case characters.Died:
+				g.events.publish(CharacterDied{c, e.Attacker})
+			case characters.Alarmed:
+				g.events.publish(EnemyAlarmed{c})
+			case characters.Landed:
+				g.events.publish(CharacterLanded{c})
+			case characters.LadderStep:
+				g.events.publish(LadderStepped{c})
+			case characters.Blocked:
+				g.events.publish(AttackBlocked{c, e.Attacker, false})
+			case characters.Parried:
+				g.events.publish(AttackBlocked{c, e.Attacker, true})
+			}
+		}
+	}
+}
+
+// completeLevel publishes LevelCompleted and delivers it at once, as there is no next frame
+func (g *Game) completeLevel() {
+	g.publishCharacterEvents()
+	g.sounds.SetListener(g.player.X, g.player.Y)
+	g.events.publish(LevelCompleted{g.Stats()})
+	g.events.dispatch()
+}
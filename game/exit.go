@@ -0,0 +1,34 @@
+package game
+
+import (
+	"log"
+	"simpleplatformer/constants"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// exit finishes the level when the player reaches it, it's drawn as a flag at its bottom
+type exit struct {
+	X       int32
+	Y       int32
+	W       int32
+	H       int32
+	texture *sdl.Texture
+}
+
+func (e *exit) touches(r sdl.Rect) bool {
+	return r.X+r.W > e.X-e.W/2 && r.X < e.X+e.W/2 && r.Y+r.H > e.Y-e.H/2 && r.Y < e.Y+e.H/2
+}
+
+func (e *exit) draw(r *sdl.Renderer) {
+	w, h := constants.TileDestWidth, constants.TileDestHeight
+	flag := &sdl.Rect{constants.TileSourceWidth * 7, 0, constants.TileSourceWidth, constants.TileSourceHeight}
+	pole := &sdl.Rect{constants.TileSourceWidth * 8, 0, constants.TileSourceWidth, constants.TileSourceHeight}
+	bottom := e.Y + e.H/2
+	if err := r.Copy(e.texture, flag, &sdl.Rect{e.X - w/2, bottom - 2*h, w, h}); err != nil {
+		log.Fatalf("could not copy exit texture: %v", err)
+	}
+	if err := r.Copy(e.texture, pole, &sdl.Rect{e.X - w/2, bottom - h, w, h}); err != nil {
+		log.Fatalf("could not copy exit texture: %v", err)
+	}
+}
@@ -2,69 +2,183 @@ package game
 
 import (
 	"log"
+	"simpleplatformer/audio"
 	"simpleplatformer/common"
 	"simpleplatformer/constants"
 	"simpleplatformer/game/characters"
+	"simpleplatformer/game/hazards"
 	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/pickups"
 	"simpleplatformer/game/platforms"
+	"simpleplatformer/game/switches"
+	"simpleplatformer/save"
+	"time"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
 
-func NewGame(texCharacters *sdl.Texture, texBackground *sdl.Texture, texSwoosh *sdl.Texture) *Game {
-	tileDestWidth := constants.TileDestWidth
-	tileDestHeight := constants.TileDestHeight
-	player := characters.NewPlayerCharacter(0, tileDestHeight*7, texCharacters, texSwoosh)
-	platforms := createPlatforms(texBackground)
-	l1, err := ladders.NewLadder(tileDestWidth*4, tileDestHeight*4+tileDestHeight/2, tileDestWidth, tileDestHeight*13, texBackground)
+// Textures groups all the textures used by the game
+type Textures struct {
+	Characters  *sdl.Texture
+	Background  *sdl.Texture
+	Swoosh      *sdl.Texture
+	Projectiles *sdl.Texture
+	Bat         *sdl.Texture
+	Hazards     *sdl.Texture
+	Pickups     *sdl.Texture
+	Mechanisms  *sdl.Texture
+	Climbables  *sdl.Texture
+}
+
+// NewGame creates the game with the level loaded from the given file, the player starts it with the given progress
+func NewGame(levelPath string, progress save.Progress, textures Textures, sounds audio.Backend) *Game {
+	level, err := loadLevelData(levelPath)
 	if err != nil {
-		log.Fatalf("could not create a ladder: %v", err)
-	}
-	ladders := []*ladders.Ladder{&l1}
-	slasher1 := characters.NewEnemyCharacter(
-		tileDestWidth*12,
-		tileDestHeight*10,
-		texCharacters,
-		texSwoosh,
-	)
-	slasher2 := characters.NewEnemyCharacter(
-		tileDestWidth*10,
-		tileDestHeight*10,
-		texCharacters,
-		texSwoosh,
-	)
-	slasher3 := characters.NewEnemyCharacter(
-		tileDestWidth*6,
-		tileDestHeight*-3,
-		texCharacters,
-		texSwoosh,
-	)
-	snake1 := characters.NewSnake(
-		tileDestWidth*20,
-		tileDestHeight*10,
-		texCharacters,
-	)
-	snake2 := characters.NewSnake(
-		tileDestWidth*22,
-		tileDestHeight*10,
-		texCharacters,
-	)
-	enemies := []*characters.Character{slasher1, slasher2, slasher3, snake1, snake2}
+		log.Fatalf("could not load level: %v", err)
+	}
+	spawnX, spawnY := tilesToX(level.Player.X), tilesToY(level.Player.Y)
+	player := characters.NewPlayerCharacter(spawnX, spawnY, textures.Characters, textures.Swoosh, textures.Projectiles)
+	weapons, weapon := loadout(progress)
+	player.Equip(weapon)
+	platforms := []*platforms.Platform{}
+	for _, pd := range level.Platforms {
+		p, err := pd.create(textures.Background)
+		if err != nil {
+			log.Fatalf("could not create a platform: %v", err)
+		}
+		platforms = append(platforms, p)
+	}
+	wiring := wiring{}
+	for i, pd := range level.Platforms {
+		if err := wiring.add(pd.ID, platforms[i]); err != nil {
+			log.Fatalf("could not wire a platform: %v", err)
+		}
+	}
+	ladders := []*ladders.Ladder{}
+	for _, ld := range level.Ladders {
+		l, err := ld.create(textures.Background, textures.Climbables)
+		if err != nil {
+			log.Fatalf("could not create a ladder: %v", err)
+		}
+		if err := wiring.add(ld.ID, l); err != nil {
+			log.Fatalf("could not wire a ladder: %v", err)
+		}
+		ladders = append(ladders, l)
+	}
+	doors := []*door{}
+	for _, dd := range level.Doors {
+		d, err := dd.create(textures.Mechanisms)
+		if err != nil {
+			log.Fatalf("could not create a door: %v", err)
+		}
+		if err := wiring.add(dd.ID, d.platform); err != nil {
+			log.Fatalf("could not wire a door: %v", err)
+		}
+		// Doors block characters like any other solid platform
+		platforms = append(platforms, d.platform)
+		doors = append(doors, d)
+	}
+	switches := []*switches.Switch{}
+	for _, sd := range level.Switches {
+		s, err := sd.create(textures.Mechanisms)
+		if err != nil {
+			log.Fatalf("could not create a switch: %v", err)
+		}
+		switches = append(switches, s)
+	}
+	if err := wiring.check(switches); err != nil {
+		log.Fatalf("could not wire switches: %v", err)
+	}
+	hazards := []*hazards.Hazard{}
+	for _, hd := range level.Hazards {
+		h, err := hd.create(textures.Hazards)
+		if err != nil {
+			log.Fatalf("could not create a hazard: %v", err)
+		}
+		hazards = append(hazards, h)
+	}
+	pickups := []*pickups.Pickup{}
+	for _, pd := range level.Pickups {
+		p, err := pd.create(textures.Pickups)
+		if err != nil {
+			log.Fatalf("could not create a pickup: %v", err)
+		}
+		pickups = append(pickups, p)
+	}
+	enemies := []*characters.Character{}
+	coordinator := newEncounterCoordinator()
 	aiControllers := []aiEnemyController{}
-	for _, e := range enemies {
-		aiCtrl, err := newAiControllerForEnemy(e)
+	for _, ed := range level.Enemies {
+		e, err := ed.create(textures)
+		if err != nil {
+			log.Fatalf("could not create an enemy: %v", err)
+		}
+		aiCtrl, err := newAiControllerForEnemy(e, coordinator)
 		if err != nil {
 			log.Fatalf("could not create enemy controller: %v", err)
 		}
+		coordinator.register(aiCtrl)
+		enemies = append(enemies, e)
 		aiControllers = append(aiControllers, aiCtrl)
 	}
-	return &Game{
-		player:        player,
-		platforms:     platforms,
-		ladders:       ladders,
-		enemies:       enemies,
-		aiControllers: aiControllers,
+	var bossFight *bossFight
+	if level.Boss != nil {
+		bossFight = level.Boss.create(textures)
+		enemies = append(enemies, bossFight.ctrl.character)
+		aiControllers = append(aiControllers, bossFight.ctrl)
+	}
+	var exit *exit
+	if level.Exit != nil {
+		exit = level.Exit.createExit(textures.Mechanisms)
+	}
+	music := level.Music
+	if music == "" {
+		music = audio.GameplayMusic
 	}
+	g := &Game{
+		player:          player,
+		platforms:       platforms,
+		activePlatforms: platforms,
+		ladders:         ladders,
+		doors:           doors,
+		switches:        switches,
+		wiring:          wiring,
+		keys:            map[string]int{},
+		path:            levelPath,
+		name:            level.Name,
+		startTime:       time.Now(),
+		enemyCount:      len(enemies),
+		collectibles:    len(pickups),
+		exit:            exit,
+		music:           music,
+		hazards:         hazards,
+		pickups:         pickups,
+		enemies:         enemies,
+		aiControllers:   aiControllers,
+		coordinator:     coordinator,
+		bossFight:       bossFight,
+		textures:        textures,
+		sounds:          sounds,
+		spawnX:          spawnX,
+		spawnY:          spawnY,
+		lives:           progress.Lives,
+		score:           score{points: progress.Score},
+		startScore:      progress.Score,
+		scoredKills:     map[*characters.Character]bool{},
+		weapons:         weapons,
+		weapon:          weapon,
+		abilities:       unlockedAbilities(progress),
+	}
+	g.unlockAbilities()
+	g.updateLadders()
+	g.Subscribe(g.scoreEvent)
+	g.Subscribe(g.playEventSound)
+	return g
+}
+
+// Score returns the points the player has got so far, including the previous levels
+func (g *Game) Score() int {
+	return g.score.points
 }
 
 // TODO: Move. Can we reuse here logic used for swooshes?
@@ -81,24 +195,196 @@ func updateEnemies(platforms []*platforms.Platform, ladders []*ladders.Ladder, e
 }
 
 type Game struct {
-	player        *characters.Character
-	platforms     []*platforms.Platform
-	ladders       []*ladders.Ladder
-	enemies       []*characters.Character
-	aiControllers []aiEnemyController
-	shiftScreenX  int32
-	shiftScreenY  int32
+	player    *characters.Character
+	platforms []*platforms.Platform
+	// activePlatforms are the platforms characters can collide with, collapsed ones are left out
+	activePlatforms []*platforms.Platform
+	ladders         []*ladders.Ladder
+	hazards         []*hazards.Hazard
+	pickups         []*pickups.Pickup
+	enemies         []*characters.Character
+	aiControllers   []aiEnemyController
+	coordinator     *encounterCoordinator
+	shiftScreenX    int32
+	shiftScreenY    int32
+	bossFight       *bossFight
+	debugOverlay    debugOverlay
+	textures        Textures
+	// spawnX and spawnY are the player start position relative to the screen at the beginning of the level
+	spawnX      int32
+	spawnY      int32
+	lives       int
+	score       score
+	scoreEvents []*scoreEvent
+	scoredKills map[*characters.Character]bool
+	// activeLadders are the ladders characters can climb, hidden ones are left out
+	activeLadders []*ladders.Ladder
+	doors         []*door
+	switches      []*switches.Switch
+	wiring        wiring
+	// keys counts the keys carried by the player for each lock, they are kept when he loses a life
+	keys         map[string]int
+	path         string
+	name         string
+	startTime    time.Time
+	startScore   int
+	pausedAt     time.Time
+	enemyCount   int
+	collected    int
+	collectibles int
+	exit         *exit
+	sounds       audio.Backend
+	// music is the track played in the level, unless there is a boss fight
+	music  string
+	events eventBus
+	// weapons is the loadout of the player, weapon the equipped one
+	weapons []characters.Weapon
+	weapon  characters.Weapon
+	// abilities are the movement abilities unlocked by the player
+	abilities []characters.Ability
 }
 
 func (g *Game) Run(r *sdl.Renderer, keyState []uint8) (common.GeneralState, bool) {
+	// Time spent in the pause menu does not count to the level time
+	if !g.pausedAt.IsZero() {
+		g.startTime = g.startTime.Add(time.Since(g.pausedAt))
+		g.pausedAt = time.Time{}
+	}
+	pressed := pressedKeys{}
 	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-		switch event.(type) {
+		switch e := event.(type) {
+		case *sdl.KeyboardEvent:
+			if sdl.K_LCTRL == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+				pressed.attack = true
+			}
+			if sdl.K_SPACE == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+				pressed.jump = true
+			}
+			if sdl.K_z == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+				pressed.dash = true
+			}
+			if sdl.K_F1 == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+				g.debugOverlay.toggle()
+			}
+			if sdl.K_UP == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+				g.useLevers()
+			}
+			if sdl.K_TAB == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+				g.switchWeapon()
+			}
+			if sdl.K_ESCAPE == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+				g.pausedAt = time.Now()
+				return common.Paused, true
+			}
 		case *sdl.QuitEvent:
 			println("Quit")
 			return 0, false
 		}
 	}
 
+	if g.bossFight == nil || !g.bossFight.isScripted() {
+		g.handleInput(keyState, pressed)
+	}
+
+	// Mechanisms go first, the platforms are built from the ladders and doors they leave active
+	g.updateMechanisms()
+	g.updatePlatforms()
+	g.applyHazards(g.player)
+	for _, e := range g.enemies {
+		g.applyHazards(e)
+	}
+	g.player.Update(g.activePlatforms, g.activeLadders, g.enemies)
+	if g.player.Y > constants.WindowHeight+g.shiftScreenY {
+		if g.lives == 0 {
+			return common.Over, true
+		}
+		g.respawn()
+	}
+	if g.exit != nil && !g.player.IsDead() && g.exit.touches(g.player.HitBox()) {
+		g.completeLevel()
+		return common.LevelComplete, true
+	}
+	if g.bossFight == nil || !g.bossFight.isLocked() {
+		g.followPlayer()
+	}
+
+	g.coordinator.update()
+	for _, ctrl := range g.aiControllers {
+		ctrl.update(g.activePlatforms, g.activeLadders, g.player, g.enemies)
+	}
+
+	g.enemies = updateEnemies(g.activePlatforms, g.activeLadders, g.enemies, g.player)
+	g.updatePickups()
+	g.updateScore()
+	g.publishCharacterEvents()
+	g.sounds.SetListener(g.player.X, g.player.Y)
+	g.events.dispatch()
+	g.sounds.PlayMusic(g.musicTrack())
+
+	if g.bossFight != nil {
+		g.bossFight.update(g)
+		if g.bossFight.isFinished() {
+			g.completeLevel()
+			return common.LevelComplete, true
+		}
+	}
+
+	r.Clear()
+	g.Draw(r)
+	r.Present()
+
+	return common.Play, true
+}
+
+// Draw draws the level without presenting it, so that menus can be drawn over it
+func (g *Game) Draw(r *sdl.Renderer) {
+	for _, p := range g.platforms {
+		p.Draw(r)
+	}
+	for _, l := range g.ladders {
+		l.Draw(r)
+	}
+	for _, s := range g.switches {
+		s.Draw(r)
+	}
+	if g.exit != nil {
+		g.exit.draw(r)
+	}
+	for _, h := range g.hazards {
+		if h.Kind != hazards.Water {
+			h.Draw(r)
+		}
+	}
+	for _, p := range g.pickups {
+		p.Draw(r)
+	}
+	for _, e := range g.enemies {
+		e.Draw(r)
+	}
+	g.player.Draw(r)
+	// Water is drawn over the characters, so they look submerged
+	for _, h := range g.hazards {
+		if h.Kind == hazards.Water {
+			h.Draw(r)
+		}
+	}
+	g.drawBreathMeter(r)
+	g.drawHUD(r)
+	if g.bossFight != nil {
+		g.bossFight.draw(r)
+	}
+	g.debugOverlay.draw(r, g)
+}
+
+// pressedKeys are the buttons pressed since the last frame. Jumps, dashes and attacks are done on presses,
+// so that a jump can be buffered and every attack can chain the next hit of a combo.
+type pressedKeys struct {
+	attack bool
+	jump   bool
+	dash   bool
+}
+
+func (g *Game) handleInput(keyState []uint8, pressed pressedKeys) {
 	if keyState[sdl.SCANCODE_LEFT] != 0 {
 		g.player.Move(-constants.CharacterVX)
 	}
@@ -108,165 +394,215 @@ func (g *Game) Run(r *sdl.Renderer, keyState []uint8) (common.GeneralState, bool
 	if keyState[sdl.SCANCODE_LEFT] == 0 && keyState[sdl.SCANCODE_RIGHT] == 0 {
 		g.player.Move(0)
 	}
-	if keyState[sdl.SCANCODE_SPACE] != 0 {
-		g.player.Jump()
+	if pressed.jump {
+		if keyState[sdl.SCANCODE_DOWN] != 0 {
+			g.player.DropDown(g.activePlatforms)
+		} else {
+			g.player.Jump()
+		}
+	}
+	// Releasing the jump button early makes the jump lower
+	if keyState[sdl.SCANCODE_SPACE] == 0 {
+		g.player.ReleaseJump()
 	}
-	if keyState[sdl.SCANCODE_LCTRL] != 0 {
-		g.player.Attack()
+	if pressed.dash {
+		g.player.Dash()
 	}
+	if pressed.attack {
+		if keyState[sdl.SCANCODE_DOWN] != 0 {
+			g.player.AttackDown()
+		} else {
+			g.player.Attack()
+		}
+	}
+	// Holding the attack button charges the heavy attack once the stamina is back
+	g.player.Charge(keyState[sdl.SCANCODE_LCTRL] != 0)
+	g.player.Block(keyState[sdl.SCANCODE_LSHIFT] != 0)
 	if keyState[sdl.SCANCODE_UP] != 0 {
-		g.player.Climb(-constants.CharacterVY, g.ladders)
+		g.player.Climb(-constants.CharacterVY, g.activeLadders)
 	}
 	if keyState[sdl.SCANCODE_DOWN] != 0 {
-		g.player.Climb(constants.CharacterVY, g.ladders)
+		g.player.Climb(constants.CharacterVY, g.activeLadders)
 	}
 	if keyState[sdl.SCANCODE_UP] == 0 && keyState[sdl.SCANCODE_DOWN] == 0 {
-		g.player.Climb(0, g.ladders)
+		g.player.Climb(0, g.activeLadders)
 	}
+}
 
-	g.player.Update(g.platforms, g.ladders, g.enemies)
-	if g.player.Y > constants.WindowHeight+g.shiftScreenY {
-		return common.Over, true
-	}
-	if g.player.IsCloseToRightScreenEdge() {
-		g.player.X -= constants.CharacterVX
-		g.shiftScreenX++
-		for _, p := range g.platforms {
-			p.X--
-		}
-		for _, l := range g.ladders {
-			l.X--
+// updatePlatforms moves the platforms before the characters, so they can be carried by them
+func (g *Game) updatePlatforms() {
+	active := []*platforms.Platform{}
+	for _, p := range g.platforms {
+		if g.player.IsStandingOn(p) {
+			p.StepOn()
 		}
 		for _, e := range g.enemies {
-			e.X--
+			if !e.IsDead() && e.IsStandingOn(p) {
+				p.StepOn()
+			}
 		}
-		for _, ctrl := range g.aiControllers {
-			ctrl.shiftPatrollingReferencePointLeft()
+		p.Update()
+		if !p.IsCollapsed() && !p.IsHidden() {
+			active = append(active, p)
 		}
 	}
-	if g.player.IsCloseToLeftScreenEdge() && g.shiftScreenX > 0 {
-		g.player.X += constants.CharacterVX
-		g.shiftScreenX--
-		for _, p := range g.platforms {
-			p.X++
+	// The tops of the ladders can be stood on
+	for _, l := range g.activeLadders {
+		if l.HasTop() {
+			active = append(active, l.Top())
 		}
-		for _, l := range g.ladders {
-			l.X++
+	}
+	g.activePlatforms = active
+}
+
+// updatePickups collects the pickups touched by the player
+func (g *Game) updatePickups() {
+	result := []*pickups.Pickup{}
+	for _, p := range g.pickups {
+		if g.player.IsDead() {
+			result = append(result, p)
+			continue
 		}
-		for _, e := range g.enemies {
-			e.X++
+		p.Update(g.player.X, g.player.Y)
+		if !p.Touches(g.player.HitBox()) {
+			result = append(result, p)
+			continue
+		}
+		g.collected++
+		switch p.Kind {
+		case pickups.HealthPotion:
+			g.player.Heal(1)
+		case pickups.StaminaBoost:
+			g.player.BoostStamina()
+		case pickups.ExtraLife:
+			g.lives++
+		case pickups.Key:
+			g.keys[p.Lock]++
+		case pickups.Sword, pickups.Spear, pickups.ThrowingKnives:
+			g.collectWeapon(weaponPickups[p.Kind])
+		case pickups.DoubleJump, pickups.WallJump, pickups.Dash:
+			g.unlockAbility(abilityPickups[p.Kind])
+		}
+		g.events.publish(PickupCollected{p})
+	}
+	g.pickups = result
+}
+
+// respawn brings the player back to the start of the level, taking one of his lives
+func (g *Game) respawn() {
+	g.lives--
+	g.shiftWorld(g.shiftScreenX, -g.shiftScreenY)
+	g.shiftScreenX, g.shiftScreenY = 0, 0
+	g.player = characters.NewPlayerCharacter(g.spawnX, g.spawnY, g.textures.Characters, g.textures.Swoosh, g.textures.Projectiles)
+	g.player.Equip(g.weapon)
+	g.unlockAbilities()
+	if g.bossFight != nil {
+		g.bossFight.reset()
+	}
+}
+
+// applyHazards hurts, kills or makes swim the character touching the hazards
+func (g *Game) applyHazards(c *characters.Character) {
+	if c.IsDead() {
+		return
+	}
+	hitBox := c.HitBox()
+	for _, h := range g.hazards {
+		if !h.Touches(hitBox) {
+			continue
 		}
-		for _, ctrl := range g.aiControllers {
-			ctrl.shiftPatrollingReferencePointRight()
+		switch h.Kind {
+		case hazards.Spikes:
+			c.Hit(characters.SpikesDamage().Towards(float32(c.X - h.X)))
+		case hazards.Pit, hazards.Lava:
+			c.Kill(0)
+		case hazards.Water:
+			c.Swim(h.SurfaceY())
 		}
 	}
+}
+
+// drawBreathMeter shows how long the player can still stay under the water
+func (g *Game) drawBreathMeter(r *sdl.Renderer) {
+	breath := int32(g.player.Breath())
+	if breath == constants.BreathMax {
+		return
+	}
+	red, green, blue, alpha, err := r.GetDrawColor()
+	if err != nil {
+		log.Fatalf("could not draw breath meter: %v", err)
+	}
+	defer r.SetDrawColor(red, green, blue, alpha)
+	bar := sdl.Rect{g.player.X - constants.BreathBarWidth/2, g.player.Y - g.player.H - constants.BreathBarHeight, constants.BreathBarWidth, constants.BreathBarHeight}
+	r.SetDrawColor(0, 0, 60, 255)
+	r.FillRect(&bar)
+	r.SetDrawColor(120, 200, 255, 255)
+	r.FillRect(&sdl.Rect{bar.X, bar.Y, bar.W * breath / constants.BreathMax, bar.H})
+}
+
+// followPlayer shifts the screen when player gets close to its edges
+func (g *Game) followPlayer() {
+	if g.player.IsCloseToRightScreenEdge() {
+		g.player.X -= constants.CharacterVX
+		g.shiftScreenX++
+		g.shiftWorld(-1, 0)
+	}
+	if g.player.IsCloseToLeftScreenEdge() && g.shiftScreenX > 0 {
+		g.player.X += constants.CharacterVX
+		g.shiftScreenX--
+		g.shiftWorld(1, 0)
+	}
 	if g.player.IsCloseToLowerScreenEdge() && g.shiftScreenY > 0 {
 		diff := g.player.Y + constants.ScreenMarginHeight - constants.WindowHeight
 		g.player.Y -= diff
 		g.shiftScreenY -= diff
-		for _, p := range g.platforms {
-			p.Y -= diff
-		}
-		for _, l := range g.ladders {
-			l.Y -= diff
-		}
-		for _, e := range g.enemies {
-			e.Y -= diff
-		}
+		g.shiftWorld(0, -diff)
 	}
 	if g.player.IsCloseToUpperScreenEdge() {
 		diff := constants.ScreenMarginHeight - g.player.Y
 		g.player.Y += diff
 		g.shiftScreenY += diff
-		for _, p := range g.platforms {
-			p.Y += diff
-		}
-		for _, l := range g.ladders {
-			l.Y += diff
-		}
-		for _, e := range g.enemies {
-			e.Y += diff
-		}
+		g.shiftWorld(0, diff)
 	}
 	if g.player.X < 0 {
 		g.player.X = 0
 	}
+}
 
-	for _, ctrl := range g.aiControllers {
-		ctrl.update(g.platforms, g.player, g.enemies)
-	}
-
-	g.enemies = updateEnemies(g.platforms, g.ladders, g.enemies, g.player)
-
-	r.Clear()
-
+// shiftWorld moves everything but the player, so that the screen follows him
+func (g *Game) shiftWorld(dx, dy int32) {
 	for _, p := range g.platforms {
-		p.Draw(r)
+		p.Shift(dx, dy)
 	}
 	for _, l := range g.ladders {
-		l.Draw(r)
+		l.X += dx
+		l.Y += dy
+	}
+	for _, h := range g.hazards {
+		h.X += dx
+		h.Y += dy
+	}
+	for _, s := range g.switches {
+		s.Shift(dx, dy)
+	}
+	if g.exit != nil {
+		g.exit.X += dx
+		g.exit.Y += dy
+	}
+	for _, p := range g.pickups {
+		p.Shift(dx, dy)
+	}
+	for _, se := range g.scoreEvents {
+		se.x += dx
+		se.y += dy
 	}
 	for _, e := range g.enemies {
-		e.Draw(r)
+		e.Shift(dx, dy)
 	}
-	g.player.Draw(r)
-
-	r.Present()
-
-	return common.Play, true
-}
-
-func createPlatforms(texBackground *sdl.Texture) []*platforms.Platform {
-	tileDestWidth := constants.TileDestWidth
-	tileDestHeight := constants.TileDestHeight
-	platform1, err := platforms.NewWalkablePlatform(tileDestWidth*6, tileDestHeight*8, tileDestWidth*5, tileDestHeight*20, texBackground)
-	if err != nil {
-		log.Fatalf("could not create a platform: %v", err)
+	for _, ctrl := range g.aiControllers {
+		ctrl.shiftPatrollingReferencePoint(dx, dy)
 	}
-	platform2, err := platforms.NewWalkablePlatform(tileDestWidth*2, tileDestHeight*14, tileDestWidth*5, tileDestHeight*6, texBackground)
-	if err != nil {
-		log.Fatalf("could not create a platform: %v", err)
+	if g.bossFight != nil {
+		g.bossFight.shift(dx, dy)
 	}
-	platform3, err := platforms.NewWalkablePlatform(tileDestWidth*19, tileDestHeight*14, tileDestWidth*22, tileDestHeight*6, texBackground)
-	if err != nil {
-		log.Fatalf("could not create a platform: %v", err)
-	}
-	// topLeftDecorationRect := &sdl.Rect{tileSourceWidth*7 + 1, 0, tileSourceWidth, tileSourceHeight - 1}
-	// topMiddleDecorationRect := &sdl.Rect{tileSourceWidth * 8, 0, tileSourceWidth, tileSourceHeight - 1}
-	// topRightDecorationRect := &sdl.Rect{tileSourceWidth * 9, 0, tileSourceWidth - 1, tileSourceHeight - 1}
-	// midMiddleDecorationRect := &sdl.Rect{tileSourceWidth*7 + 1, tileDestHeight, tileSourceWidth - 2, tileSourceHeight - 1}
-	// msg := "could not add decoration to platform2: %v"
-	// err = platform2.addDecoration(topLeftDecorationRect, tileDestWidth*2, 0)
-	// if err != nil {
-	// 	log.Fatalf(msg, err)
-	// }
-	// err = platform2.addDecoration(topMiddleDecorationRect, tileDestWidth*3, 0)
-	// if err != nil {
-	// 	log.Fatalf(msg, err)
-	// }
-	// err = platform2.addDecoration(topRightDecorationRect, tileDestWidth*4, 0)
-	// if err != nil {
-	// 	log.Fatalf(msg, err)
-	// }
-	// err = platform2.addDecoration(topLeftDecorationRect, tileDestWidth*10, 0)
-	// if err != nil {
-	// 	log.Fatalf(msg, err)
-	// }
-	// err = platform2.addDecoration(topMiddleDecorationRect, tileDestWidth*11, 0)
-	// if err != nil {
-	// 	log.Fatalf(msg, err)
-	// }
-	// err = platform2.addDecoration(topRightDecorationRect, tileDestWidth*12, 0)
-	// if err != nil {
-	// 	log.Fatalf(msg, err)
-	// }
-	// err = platform2.addDecoration(midMiddleDecorationRect, tileDestWidth*3, tileDestHeight)
-	// if err != nil {
-	// 	log.Fatalf(msg, err)
-	// }
-	// err = platform2.addDecoration(midMiddleDecorationRect, tileDestWidth*7, tileDestHeight*2)
-	// if err != nil {
-	// 	log.Fatalf(msg, err)
-	// }
-	return []*platforms.Platform{&platform1, &platform2, &platform3}
 }
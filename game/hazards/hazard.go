@@ -0,0 +1,112 @@
+package hazards
+
+import (
+	"fmt"
+	"log"
+	"simpleplatformer/common"
+	"simpleplatformer/constants"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+type Kind int
+
+const (
+	// Spikes hurt characters touching them
+	Spikes Kind = iota
+	// Pit kills characters falling into it, it's not drawn
+	Pit
+	// Lava kills characters touching it
+	Lava
+	// Water makes characters swim, they lose breath when under the surface
+	Water
+)
+
+// ParseKind returns hazard kind of the given name
+func ParseKind(name string) (Kind, error) {
+	switch name {
+	case "spikes":
+		return Spikes, nil
+	case "pit":
+		return Pit, nil
+	case "lava":
+		return Lava, nil
+	case "water":
+		return Water, nil
+	}
+	return 0, fmt.Errorf("unknown hazard: %v", name)
+}
+
+type hazardRects struct {
+	surfaceRect *sdl.Rect
+	bodyRect    *sdl.Rect
+}
+
+func newHazardRect(pos common.RelativeRectPosition) *sdl.Rect {
+	return &sdl.Rect{
+		constants.TileSourceWidth * int32(pos.XIndex),
+		constants.TileSourceHeight * int32(pos.YIndex),
+		constants.TileSourceWidth,
+		constants.TileSourceHeight,
+	}
+}
+
+func NewHazard(kind Kind, x, y, w, h int32, texture *sdl.Texture) (Hazard, error) {
+	if w < constants.TileDestWidth {
+		return Hazard{}, fmt.Errorf("invalid hazard width provided: %v. Must be at least %v", w, constants.TileDestWidth)
+	}
+	if h < constants.TileDestHeight {
+		return Hazard{}, fmt.Errorf("invalid hazard height provided: %v. Must be at least %v", h, constants.TileDestHeight)
+	}
+	var rects hazardRects
+	switch kind {
+	case Spikes:
+		spikes := newHazardRect(common.RelativeRectPosition{0, 0})
+		rects = hazardRects{spikes, spikes}
+	case Water:
+		rects = hazardRects{newHazardRect(common.RelativeRectPosition{1, 0}), newHazardRect(common.RelativeRectPosition{2, 0})}
+	case Lava:
+		rects = hazardRects{newHazardRect(common.RelativeRectPosition{3, 0}), newHazardRect(common.RelativeRectPosition{4, 0})}
+	}
+	return Hazard{x, y, w, h, kind, texture, rects}, nil
+}
+
+type Hazard struct {
+	X           int32
+	Y           int32
+	W           int32
+	H           int32
+	Kind        Kind
+	texture     *sdl.Texture
+	sourceRects hazardRects
+}
+
+// Touches returns true if the rectangle overlaps the hazard
+func (h *Hazard) Touches(r sdl.Rect) bool {
+	return r.X+r.W > h.X-h.W/2 && r.X < h.X+h.W/2 && r.Y+r.H > h.Y-h.H/2 && r.Y < h.Y+h.H/2
+}
+
+// SurfaceY returns the y coordinate of the hazard top
+func (h *Hazard) SurfaceY() int32 {
+	return h.Y - h.H/2
+}
+
+func (h *Hazard) Draw(renderer *sdl.Renderer) {
+	if h.Kind == Pit {
+		return
+	}
+	tileDestWidth := constants.TileDestWidth
+	tileDestHeight := constants.TileDestHeight
+	for y := int32(0); y < h.H; y += tileDestHeight {
+		src := h.sourceRects.bodyRect
+		if y == 0 {
+			src = h.sourceRects.surfaceRect
+		}
+		for x := int32(0); x < h.W; x += tileDestWidth {
+			err := renderer.Copy(h.texture, src, &sdl.Rect{h.X - h.W/2 + x, h.Y - h.H/2 + y, tileDestWidth, tileDestHeight})
+			if err != nil {
+				log.Fatalf("could not copy hazard texture: %v", err)
+			}
+		}
+	}
+}
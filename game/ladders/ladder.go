@@ -5,29 +5,76 @@ import (
 	"log"
 	"simpleplatformer/common"
 	"simpleplatformer/constants"
+	"simpleplatformer/game/platforms"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
 
+type Kind int
+
+const (
+	// Wooden ladders can be stood on at the top
+	Wooden Kind = iota
+	// Ropes and vines hang from what is above them, they cannot be climbed over
+	Rope
+	Vine
+)
+
+// ParseKind returns ladder kind of the given name
+func ParseKind(name string) (Kind, error) {
+	switch name {
+	case "ladder":
+		return Wooden, nil
+	case "rope":
+		return Rope, nil
+	case "vine":
+		return Vine, nil
+	}
+	return 0, fmt.Errorf("unknown ladder: %v", name)
+}
+
 type ladderRects struct {
 	topRect *sdl.Rect
 	midRect *sdl.Rect
 	botRect *sdl.Rect
 }
 
-func NewLadder(x, y, w, h int32, texture *sdl.Texture) (Ladder, error) {
+// NewLadder creates a ladder, rope or vine. It can be a single tile high, such a ladder shows only its top.
+// Wooden ladders are drawn from the background texture, ropes and vines from the climbables one.
+func NewLadder(x, y, w, h int32, kind Kind, texture *sdl.Texture) (Ladder, error) {
 	if w < constants.TileDestWidth {
 		return Ladder{}, fmt.Errorf("invalid ladder width provided: %v. Must be at least %v", w, constants.TileDestWidth)
 	}
-	if h < 2*constants.TileDestHeight {
-		return Ladder{}, fmt.Errorf("invalid ladder height provided: %v. Must be at least %v", h, 2*constants.TileDestHeight)
+	if h < constants.TileDestHeight {
+		return Ladder{}, fmt.Errorf("invalid ladder height provided: %v. Must be at least %v", h, constants.TileDestHeight)
 	}
-	rects := ladderRects{
-		topRect: newLadderRect(common.RelativeRectPosition{7, 4}),
-		midRect: newLadderRect(common.RelativeRectPosition{7, 5}),
-		botRect: newLadderRect(common.RelativeRectPosition{7, 6}),
+	var rects ladderRects
+	switch kind {
+	case Rope:
+		rects = ladderRects{
+			topRect: newClimbableRect(0),
+			midRect: newClimbableRect(1),
+			botRect: newClimbableRect(2),
+		}
+	case Vine:
+		rects = ladderRects{
+			topRect: newClimbableRect(3),
+			midRect: newClimbableRect(4),
+			botRect: newClimbableRect(5),
+		}
+	default:
+		rects = ladderRects{
+			topRect: newLadderRect(common.RelativeRectPosition{7, 4}),
+			midRect: newLadderRect(common.RelativeRectPosition{7, 5}),
+			botRect: newLadderRect(common.RelativeRectPosition{7, 6}),
+		}
 	}
-	return Ladder{x, y, w, h, texture, rects}, nil
+	return Ladder{x, y, w, h, kind, texture, rects, false, platforms.NewLadderTop(x, y-h/2, w)}, nil
+}
+
+// newClimbableRect returns the rope or vine tile of the given index in the climbables texture
+func newClimbableRect(index int32) *sdl.Rect {
+	return &sdl.Rect{constants.TileSourceWidth * index, 0, constants.TileSourceWidth, constants.TileSourceHeight}
 }
 
 // TODO: Duplication from platforms package, refactor.
@@ -45,17 +92,54 @@ type Ladder struct {
 	Y           int32
 	W           int32
 	H           int32
+	Kind        Kind
 	texture     *sdl.Texture
 	sourceRects ladderRects
+	// hidden ladders are not drawn and cannot be climbed
+	hidden bool
+	top    platforms.Platform
+}
+
+// HasTop returns true if characters can stand on top of the ladder
+func (l *Ladder) HasTop() bool {
+	return l.Kind == Wooden
+}
+
+// Top returns the platform characters stand on at the top of the ladder, it follows the ladder when it's shifted
+func (l *Ladder) Top() *platforms.Platform {
+	l.top.X = l.X
+	l.top.Y = l.Y - l.H/2 + l.top.H/2
+	return &l.top
+}
+
+// SetHidden makes the ladder disappear or show up again
+func (l *Ladder) SetHidden(hidden bool) {
+	l.hidden = hidden
+}
+
+// Toggle shows the hidden ladder or hides the visible one
+func (l *Ladder) Toggle() {
+	l.hidden = !l.hidden
+}
+
+// IsHidden returns true if the ladder is not there for now
+func (l *Ladder) IsHidden() bool {
+	return l.hidden
 }
 
 func (l *Ladder) Draw(renderer *sdl.Renderer) {
+	if l.hidden {
+		return
+	}
 	dst := &sdl.Rect{l.X - l.W/2, l.Y - l.H/2, constants.TileDestWidth, constants.TileDestHeight}
 	// Draw top
 	err := renderer.Copy(l.texture, l.sourceRects.topRect, dst)
 	if err != nil {
 		log.Fatalf("could not copy ladder texture (top): %v", err)
 	}
+	if l.H < 2*constants.TileDestHeight {
+		return
+	}
 	// Draw bottom
 	dst = &sdl.Rect{l.X - l.W/2, l.Y + l.H/2 - constants.TileDestHeight, constants.TileDestWidth, constants.TileDestHeight}
 	err = renderer.Copy(l.texture, l.sourceRects.botRect, dst)
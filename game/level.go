@@ -0,0 +1,292 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/characters"
+	"simpleplatformer/game/hazards"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/pickups"
+	"simpleplatformer/game/platforms"
+	"simpleplatformer/game/switches"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// levelData describes a level loaded from a file, all positions and sizes are given in tiles
+type levelData struct {
+	Name      string         `json:"name"`
+	Player    pointData      `json:"player"`
+	Platforms []platformData `json:"platforms"`
+	Ladders   []ladderData   `json:"ladders"`
+	Doors     []doorData     `json:"doors"`
+	Switches  []switchData   `json:"switches"`
+	Hazards   []hazardData   `json:"hazards"`
+	Pickups   []pickupData   `json:"pickups"`
+	Enemies   []enemyData    `json:"enemies"`
+	Boss      *bossData      `json:"boss"`
+	// Exit finishes the level when the player reaches it, levels with a boss are finished by defeating him
+	Exit *rectData `json:"exit"`
+	// Music is the name of the track from the music manifest, the default gameplay track is used if empty
+	Music string `json:"music"`
+}
+
+type pointData struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type rectData struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+type platformData struct {
+	rectData
+	// Material is one of ground (default), crumbling, bouncy or ice
+	Material string `json:"material"`
+	// Bounce is the vertical speed given by a bouncy platform, default is used if not set
+	Bounce float32 `json:"bounce"`
+	// Slope is the angle of the platform surface in degrees, 0 for flat platforms
+	Slope float64 `json:"slope"`
+	// Rises is the side the slope goes up to, either left or right
+	Rises string `json:"rises"`
+	// Collision is one of oneway (default), solid or dropthrough
+	Collision string    `json:"collision"`
+	Path      *pathData `json:"path"`
+	// ID is used to wire the platform to switches
+	ID string `json:"id"`
+	// Hidden platforms are not there until a switch toggles them
+	Hidden bool `json:"hidden"`
+}
+
+// pathData describes how the platform moves, waypoints are relative to the platform position
+type pathData struct {
+	Mode      string       `json:"mode"`
+	Speed     float32      `json:"speed"`
+	Waypoints [][2]float64 `json:"waypoints"`
+}
+
+type ladderData struct {
+	rectData
+	// Type is one of ladder (default), rope or vine
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Hidden bool   `json:"hidden"`
+}
+
+// doorData describes a one tile wide door, it's closed at the beginning of the level
+type doorData struct {
+	ID string  `json:"id"`
+	X  float64 `json:"x"`
+	Y  float64 `json:"y"`
+	H  float64 `json:"h"`
+	// Lock is the name of the key opening the door, doors without a lock are opened by switches only
+	Lock string `json:"lock"`
+}
+
+type switchData struct {
+	// Type is one of lever or plate
+	Type    string   `json:"type"`
+	X       float64  `json:"x"`
+	Y       float64  `json:"y"`
+	Targets []string `json:"targets"`
+}
+
+type hazardData struct {
+	rectData
+	// Type is one of spikes, pit, lava or water
+	Type string `json:"type"`
+}
+
+type pickupData struct {
+	// Type is one of coin, gem, potion, stamina, life or key
+	Type string  `json:"type"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+	// Lock is the name of the doors opened by a key
+	Lock string `json:"lock"`
+}
+
+type enemyData struct {
+	Type string  `json:"type"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+type bossData struct {
+	Name  string   `json:"name"`
+	X     float64  `json:"x"`
+	Y     float64  `json:"y"`
+	Arena rectData `json:"arena"`
+}
+
+func loadLevelData(path string) (levelData, error) {
+	data := levelData{}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return data, fmt.Errorf("could not read level file: %v", err)
+	}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return data, fmt.Errorf("could not parse level file %v: %v", path, err)
+	}
+	return data, nil
+}
+
+func tilesToX(tiles float64) int32 {
+	return int32(tiles * float64(constants.TileDestWidth))
+}
+
+func tilesToY(tiles float64) int32 {
+	return int32(tiles * float64(constants.TileDestHeight))
+}
+
+func (pd *platformData) create(texBackground *sdl.Texture) (*platforms.Platform, error) {
+	x, y, w, h := tilesToX(pd.X), tilesToY(pd.Y), tilesToX(pd.W), tilesToY(pd.H)
+	var p platforms.Platform
+	var err error
+	switch pd.Material {
+	case "", "ground":
+		p, err = platforms.NewWalkablePlatform(x, y, w, h, texBackground)
+	case "crumbling":
+		p, err = platforms.NewCrumblingPlatform(x, y, w, h, texBackground)
+	case "bouncy":
+		bounce := pd.Bounce
+		if bounce == 0 {
+			bounce = constants.BounceImpulse
+		}
+		p, err = platforms.NewBouncyPlatform(x, y, w, h, bounce, texBackground)
+	case "ice":
+		p, err = platforms.NewIcyPlatform(x, y, w, h, texBackground)
+	default:
+		err = fmt.Errorf("unknown platform material: %v", pd.Material)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pd.Slope != 0 {
+		if pd.Rises != "left" && pd.Rises != "right" {
+			return nil, fmt.Errorf("invalid slope side: %v", pd.Rises)
+		}
+		if err := p.SetSlope(pd.Slope, pd.Rises == "right"); err != nil {
+			return nil, err
+		}
+	}
+	if pd.Collision != "" {
+		mode, err := platforms.ParseCollisionMode(pd.Collision)
+		if err != nil {
+			return nil, err
+		}
+		p.SetCollisionMode(mode)
+	}
+	if pd.Path != nil {
+		mode, err := platforms.ParsePathMode(pd.Path.Mode)
+		if err != nil {
+			return nil, err
+		}
+		waypoints := []sdl.Point{}
+		for _, w := range pd.Path.Waypoints {
+			waypoints = append(waypoints, sdl.Point{tilesToX(w[0]), tilesToY(w[1])})
+		}
+		path, err := platforms.NewPath(mode, waypoints, pd.Path.Speed)
+		if err != nil {
+			return nil, err
+		}
+		p.SetPath(path)
+	}
+	p.SetHidden(pd.Hidden)
+	return &p, nil
+}
+
+func (ld *ladderData) create(texBackground, texClimbables *sdl.Texture) (*ladders.Ladder, error) {
+	kind, texture := ladders.Wooden, texBackground
+	if ld.Type != "" {
+		k, err := ladders.ParseKind(ld.Type)
+		if err != nil {
+			return nil, err
+		}
+		kind = k
+	}
+	if kind != ladders.Wooden {
+		texture = texClimbables
+	}
+	l, err := ladders.NewLadder(tilesToX(ld.X), tilesToY(ld.Y), tilesToX(ld.W), tilesToY(ld.H), kind, texture)
+	if err != nil {
+		return nil, err
+	}
+	l.SetHidden(ld.Hidden)
+	return &l, nil
+}
+
+func (dd *doorData) create(texMechanisms *sdl.Texture) (*door, error) {
+	p, err := platforms.NewDoor(tilesToX(dd.X), tilesToY(dd.Y), tilesToY(dd.H), dd.Lock != "", texMechanisms)
+	if err != nil {
+		return nil, err
+	}
+	return &door{&p, dd.Lock}, nil
+}
+
+func (sd *switchData) create(texMechanisms *sdl.Texture) (*switches.Switch, error) {
+	kind, err := switches.ParseKind(sd.Type)
+	if err != nil {
+		return nil, err
+	}
+	if len(sd.Targets) == 0 {
+		return nil, fmt.Errorf("%v is not wired to anything", sd.Type)
+	}
+	return switches.NewSwitch(kind, tilesToX(sd.X), tilesToY(sd.Y), sd.Targets, texMechanisms), nil
+}
+
+func (hd *hazardData) create(texHazards *sdl.Texture) (*hazards.Hazard, error) {
+	kind, err := hazards.ParseKind(hd.Type)
+	if err != nil {
+		return nil, err
+	}
+	h, err := hazards.NewHazard(kind, tilesToX(hd.X), tilesToY(hd.Y), tilesToX(hd.W), tilesToY(hd.H), texHazards)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (pd *pickupData) create(texPickups *sdl.Texture) (*pickups.Pickup, error) {
+	kind, err := pickups.ParseKind(pd.Type)
+	if err != nil {
+		return nil, err
+	}
+	if kind == pickups.Key && pd.Lock == "" {
+		return nil, fmt.Errorf("key does not open any lock")
+	}
+	p := pickups.NewPickup(kind, tilesToX(pd.X), tilesToY(pd.Y), texPickups)
+	p.Lock = pd.Lock
+	return p, nil
+}
+
+func (ed *enemyData) create(textures Textures) (*characters.Character, error) {
+	x, y := tilesToX(ed.X), tilesToY(ed.Y)
+	switch ed.Type {
+	case "slasher":
+		return characters.NewEnemyCharacter(x, y, textures.Characters, textures.Swoosh), nil
+	case "snake":
+		return characters.NewSnake(x, y, textures.Characters), nil
+	case "archer":
+		return characters.NewArcher(x, y, textures.Characters, textures.Projectiles), nil
+	case "bat":
+		return characters.NewBat(x, y, textures.Bat), nil
+	}
+	return nil, fmt.Errorf("unknown enemy type: %v", ed.Type)
+}
+
+func (ed *rectData) createExit(texMechanisms *sdl.Texture) *exit {
+	return &exit{tilesToX(ed.X), tilesToY(ed.Y), tilesToX(ed.W), tilesToY(ed.H), texMechanisms}
+}
+
+func (bd *bossData) create(textures Textures) *bossFight {
+	boss := characters.NewBoss(tilesToX(bd.X), tilesToY(bd.Y), textures.Characters, textures.Swoosh)
+	a := arena{tilesToX(bd.Arena.X), tilesToY(bd.Arena.Y), tilesToX(bd.Arena.W), tilesToY(bd.Arena.H)}
+	return newBossFight(bd.Name, a, boss, textures.Background)
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"fmt"
+	"simpleplatformer/game/ladders"
+	"simpleplatformer/game/platforms"
+	"simpleplatformer/game/switches"
+)
+
+// door is a solid platform blocking the way until it's opened by a key or a switch
+type door struct {
+	platform *platforms.Platform
+	// lock is the name of the key opening the door, empty for doors opened by switches only
+	lock string
+}
+
+// toggler is a level entity switches can be wired to
+type toggler interface {
+	Toggle()
+}
+
+// wiring maps ids of the level entities to them, so switches can find their targets
+type wiring map[string]toggler
+
+func (w wiring) add(id string, t toggler) error {
+	if id == "" {
+		return nil
+	}
+	if _, ok := w[id]; ok {
+		return fmt.Errorf("duplicate id: %v", id)
+	}
+	w[id] = t
+	return nil
+}
+
+// check returns an error if any switch is wired to an id not present in the level
+func (w wiring) check(sws []*switches.Switch) error {
+	for _, s := range sws {
+		for _, target := range s.Targets {
+			if _, ok := w[target]; !ok {
+				return fmt.Errorf("switch wired to unknown id: %v", target)
+			}
+		}
+	}
+	return nil
+}
+
+// updateMechanisms presses the plates characters step on and opens the locked doors the player has keys for
+func (g *Game) updateMechanisms() {
+	for _, s := range g.switches {
+		if s.Kind != switches.PressurePlate || s.IsOn() {
+			continue
+		}
+		for _, c := range append(g.enemies, g.player) {
+			if !c.IsDead() && s.Touches(c.HitBox()) {
+				g.activateSwitch(s)
+				break
+			}
+		}
+	}
+	hitBox := g.player.HitBox()
+	for _, d := range g.doors {
+		p := d.platform
+		if d.lock == "" || p.IsHidden() || g.keys[d.lock] == 0 || g.player.IsDead() {
+			continue
+		}
+		if hitBox.X+hitBox.W > p.X-p.W/2 && hitBox.X < p.X+p.W/2 && hitBox.Y+hitBox.H > p.Y-p.H/2 && hitBox.Y < p.Y+p.H/2 {
+			g.keys[d.lock]--
+			d.lock = ""
+			p.SetHidden(true)
+		}
+	}
+	g.updateLadders()
+}
+
+// useLevers flips the levers the player stands at
+func (g *Game) useLevers() {
+	if g.player.IsDead() {
+		return
+	}
+	for _, s := range g.switches {
+		if s.Kind == switches.Lever && s.Touches(g.player.HitBox()) {
+			g.activateSwitch(s)
+		}
+	}
+}
+
+func (g *Game) activateSwitch(s *switches.Switch) {
+	if !s.Activate() {
+		return
+	}
+	for _, target := range s.Targets {
+		g.wiring[target].Toggle()
+	}
+}
+
+// updateLadders leaves out the hidden ladders, so they cannot be climbed
+func (g *Game) updateLadders() {
+	active := []*ladders.Ladder{}
+	for _, l := range g.ladders {
+		if !l.IsHidden() {
+			active = append(active, l)
+		}
+	}
+	g.activeLadders = active
+}
+
+// keyCount returns the number of keys carried by the player
+func (g *Game) keyCount() int {
+	result := 0
+	for _, n := range g.keys {
+		result += n
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+package pickups
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"simpleplatformer/constants"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+type Kind int
+
+const (
+	Coin Kind = iota
+	Gem
+	HealthPotion
+	StaminaBoost
+	ExtraLife
+	// Key opens the locked doors with the same lock
+	Key
+	// Weapons are added to the player loadout
+	Sword
+	Spear
+	ThrowingKnives
+	// Abilities are unlocked for the rest of the campaign
+	DoubleJump
+	WallJump
+	Dash
+)
+
+// ParseKind returns pickup kind of the given name
+func ParseKind(name string) (Kind, error) {
+	switch name {
+	case "coin":
+		return Coin, nil
+	case "gem":
+		return Gem, nil
+	case "potion":
+		return HealthPotion, nil
+	case "stamina":
+		return StaminaBoost, nil
+	case "life":
+		return ExtraLife, nil
+	case "key":
+		return Key, nil
+	case "sword":
+		return Sword, nil
+	case "spear":
+		return Spear, nil
+	case "knives":
+		return ThrowingKnives, nil
+	case "doublejump":
+		return DoubleJump, nil
+	case "walljump":
+		return WallJump, nil
+	case "dash":
+		return Dash, nil
+	}
+	return 0, fmt.Errorf("unknown pickup: %v", name)
+}
+
+// newPickupAnimationRects returns frames of the pickup kind, each kind has its own row in the texture
+func newPickupAnimationRects(kind Kind) []*sdl.Rect {
+	result := []*sdl.Rect{}
+	for i := int32(0); i < 4; i++ {
+		result = append(result, &sdl.Rect{
+			constants.TileSourceWidth * i,
+			constants.TileSourceHeight * int32(kind),
+			constants.TileSourceWidth,
+			constants.TileSourceHeight,
+		})
+	}
+	return result
+}
+
+// Pickup is an item collected by the player touching it
+type Pickup struct {
+	X       int32
+	Y       int32
+	Kind    Kind
+	x       float32
+	y       float32
+	time    int
+	texture *sdl.Texture
+	rects   []*sdl.Rect
+	// Lock is the lock opened by a key
+	Lock string
+}
+
+func NewPickup(kind Kind, x, y int32, texture *sdl.Texture) *Pickup {
+	return &Pickup{
+		X:       x,
+		Y:       y,
+		Kind:    kind,
+		x:       float32(x),
+		y:       float32(y),
+		time:    0,
+		texture: texture,
+		rects:   newPickupAnimationRects(kind),
+	}
+}
+
+// Score returns points given for collecting the pickup
+func (p *Pickup) Score() int {
+	switch p.Kind {
+	case Coin:
+		return constants.CoinScore
+	case Gem:
+		return constants.GemScore
+	}
+	return 0
+}
+
+// Update animates the pickup and pulls it towards the player when he's close
+func (p *Pickup) Update(playerX, playerY int32) {
+	p.time++
+	dx := float32(playerX) - p.x
+	dy := float32(playerY) - p.y
+	distance := float32(math.Sqrt(float64(dx*dx + dy*dy)))
+	if distance > 0 && distance < constants.PickupMagnetRange {
+		p.x += dx / distance * constants.PickupMagnetSpeed
+		p.y += dy / distance * constants.PickupMagnetSpeed
+	}
+	p.X, p.Y = int32(p.x), int32(p.y)
+}
+
+// Shift moves the pickup together with the world
+func (p *Pickup) Shift(dx, dy int32) {
+	p.x += float32(dx)
+	p.y += float32(dy)
+	p.X, p.Y = int32(p.x), int32(p.y)
+}
+
+// Touches returns true if the rectangle overlaps the pickup
+func (p *Pickup) Touches(r sdl.Rect) bool {
+	w, h := constants.TileDestWidth, constants.TileDestHeight
+	return r.X+r.W > p.X-w/2 && r.X < p.X+w/2 && r.Y+r.H > p.Y-h/2 && r.Y < p.Y+h/2
+}
+
+func (p *Pickup) Draw(renderer *sdl.Renderer) {
+	w, h := constants.TileDestWidth, constants.TileDestHeight
+	// Bob up and down
+	bobY := int32(math.Sin(float64(p.time)*constants.PickupBobFrequency) * constants.PickupBobAmplitude)
+	src := p.rects[p.time/8%len(p.rects)]
+	err := renderer.Copy(p.texture, src, &sdl.Rect{p.X - w/2, p.Y - h/2 + bobY, w, h})
+	if err != nil {
+		log.Fatalf("could not copy pickup texture: %v", err)
+	}
+}
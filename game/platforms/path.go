@@ -0,0 +1,126 @@
+package platforms
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+type PathMode int
+
+const (
+	// PathLinear goes through the waypoints once
+	PathLinear PathMode = iota
+	// PathPingPong goes through the waypoints back and forth
+	PathPingPong
+	// PathLoop goes from the last waypoint straight to the first one
+	PathLoop
+	// PathTriggered waits on the first waypoint until triggered, then goes through the waypoints and comes back
+	PathTriggered
+)
+
+// ParsePathMode returns path mode of the given name
+func ParsePathMode(name string) (PathMode, error) {
+	switch name {
+	case "linear":
+		return PathLinear, nil
+	case "pingpong":
+		return PathPingPong, nil
+	case "loop":
+		return PathLoop, nil
+	case "triggered":
+		return PathTriggered, nil
+	}
+	return 0, fmt.Errorf("unknown path mode: %v", name)
+}
+
+// Path makes the platform move through the waypoints given relative to its starting position
+type Path struct {
+	mode      PathMode
+	waypoints []sdl.Point
+	speed     float32
+	target    int
+	direction int
+	offsetX   float32
+	offsetY   float32
+	active    bool
+}
+
+func NewPath(mode PathMode, waypoints []sdl.Point, speed float32) (*Path, error) {
+	if len(waypoints) < 2 {
+		return nil, fmt.Errorf("path needs at least 2 waypoints, got %v", len(waypoints))
+	}
+	if speed <= 0 {
+		return nil, fmt.Errorf("invalid path speed: %v", speed)
+	}
+	return &Path{
+		mode:      mode,
+		waypoints: waypoints,
+		speed:     speed,
+		target:    1,
+		direction: 1,
+		offsetX:   float32(waypoints[0].X),
+		offsetY:   float32(waypoints[0].Y),
+		active:    mode != PathTriggered,
+	}, nil
+}
+
+// update moves the path position towards the next waypoint and returns the change of the position
+func (pt *Path) update() (int32, int32) {
+	if !pt.active {
+		return 0, 0
+	}
+	prevX, prevY := int32(pt.offsetX), int32(pt.offsetY)
+	target := pt.waypoints[pt.target]
+	dx := float32(target.X) - pt.offsetX
+	dy := float32(target.Y) - pt.offsetY
+	distance := float32(math.Sqrt(float64(dx*dx + dy*dy)))
+	if distance <= pt.speed {
+		pt.offsetX, pt.offsetY = float32(target.X), float32(target.Y)
+		pt.nextWaypoint()
+	} else {
+		pt.offsetX += dx / distance * pt.speed
+		pt.offsetY += dy / distance * pt.speed
+	}
+	return int32(pt.offsetX) - prevX, int32(pt.offsetY) - prevY
+}
+
+func (pt *Path) nextWaypoint() {
+	last := len(pt.waypoints) - 1
+	switch pt.mode {
+	case PathLinear:
+		if pt.target == last {
+			pt.active = false
+			return
+		}
+	case PathLoop:
+		if pt.target == last {
+			pt.target = 0
+			return
+		}
+	case PathPingPong:
+		if pt.target == last {
+			pt.direction = -1
+		} else if pt.target == 0 {
+			pt.direction = 1
+		}
+	case PathTriggered:
+		if pt.target == last {
+			pt.direction = -1
+		} else if pt.target == 0 {
+			// Back on the start, wait for another trigger
+			pt.direction = 1
+			pt.target = 1
+			pt.active = false
+			return
+		}
+	}
+	pt.target += pt.direction
+}
+
+func (pt *Path) trigger() {
+	if pt.mode == PathTriggered {
+		pt.active = true
+	}
+}
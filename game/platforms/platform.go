@@ -3,6 +3,7 @@ package platforms
 import (
 	"fmt"
 	"log"
+	"math"
 	"simpleplatformer/common"
 	"simpleplatformer/constants"
 
@@ -40,6 +41,39 @@ func (pd *platformDecoration) draw(renderer *sdl.Renderer) {
 	}
 }
 
+type CollisionMode int
+
+const (
+	// CollisionOneWay platforms can be jumped through from below and landed on from above
+	CollisionOneWay CollisionMode = iota
+	// CollisionSolid platforms block characters from every side
+	CollisionSolid
+	// CollisionDropThrough platforms are one-way and characters can also drop down through them
+	CollisionDropThrough
+)
+
+// ParseCollisionMode returns collision mode of the given name
+func ParseCollisionMode(name string) (CollisionMode, error) {
+	switch name {
+	case "oneway":
+		return CollisionOneWay, nil
+	case "solid":
+		return CollisionSolid, nil
+	case "dropthrough":
+		return CollisionDropThrough, nil
+	}
+	return 0, fmt.Errorf("unknown collision mode: %v", name)
+}
+
+type material int
+
+const (
+	materialGround material = iota
+	materialCrumbling
+	materialBouncy
+	materialIce
+)
+
 type Platform struct {
 	X           int32
 	Y           int32
@@ -48,6 +82,21 @@ type Platform struct {
 	texture     *sdl.Texture
 	sourceRects platformRects
 	decorations []platformDecoration
+	collision   CollisionMode
+	material    material
+	path        *Path
+	// bounceImpulse is the vertical speed given to characters landing on a bouncy platform
+	bounceImpulse float32
+	// crumbleTime counts frames since a crumbling platform has been stepped on
+	crumbleTime int
+	// gradient is the slope of the platform surface, 0 for flat platforms
+	gradient   float64
+	risesRight bool
+	// DX and DY hold how far the platform moved during the last update
+	DX int32
+	DY int32
+	// hidden platforms are not drawn and characters pass through them
+	hidden bool
 }
 
 func NewWalkablePlatform(x, y, w, h int32, texture *sdl.Texture) (Platform, error) {
@@ -62,11 +111,241 @@ func NewWalkablePlatform(x, y, w, h int32, texture *sdl.Texture) (Platform, erro
 	return newPlatform(x, y, w, h, texture, walkablePlatformRects)
 }
 
+// NewCrumblingPlatform creates a platform made of crates that collapses a while after being stepped on
+func NewCrumblingPlatform(x, y, w, h int32, texture *sdl.Texture) (Platform, error) {
+	crate := newPlatformRect(common.RelativeRectPosition{8, 4})
+	crumblingPlatformRects := platformRects{
+		topLeftRect:   crate,
+		topMiddleRect: crate,
+		topRightRect:  crate,
+		midLeftRect:   crate,
+		midMiddleRect: crate,
+		midRightRect:  crate,
+	}
+	p, err := newPlatform(x, y, w, h, texture, crumblingPlatformRects)
+	p.material = materialCrumbling
+	return p, err
+}
+
+// NewBouncyPlatform creates a bush that launches characters landing on it with the given vertical speed
+func NewBouncyPlatform(x, y, w, h int32, impulse float32, texture *sdl.Texture) (Platform, error) {
+	if impulse <= 0 {
+		return Platform{}, fmt.Errorf("invalid bounce impulse: %v", impulse)
+	}
+	bouncyPlatformRects := platformRects{
+		topLeftRect:   newPlatformRect(common.RelativeRectPosition{11, 4}),
+		topMiddleRect: newPlatformRect(common.RelativeRectPosition{12, 4}),
+		topRightRect:  newPlatformRect(common.RelativeRectPosition{13, 4}),
+		midLeftRect:   newPlatformRect(common.RelativeRectPosition{11, 5}),
+		midMiddleRect: newPlatformRect(common.RelativeRectPosition{12, 5}),
+		midRightRect:  newPlatformRect(common.RelativeRectPosition{13, 5}),
+	}
+	p, err := newPlatform(x, y, w, h, texture, bouncyPlatformRects)
+	p.material = materialBouncy
+	p.bounceImpulse = impulse
+	return p, err
+}
+
+// NewIcyPlatform creates a slippery platform
+func NewIcyPlatform(x, y, w, h int32, texture *sdl.Texture) (Platform, error) {
+	icyPlatformRects := platformRects{
+		topLeftRect:   newPlatformRect(common.RelativeRectPosition{10, 2}),
+		topMiddleRect: newPlatformRect(common.RelativeRectPosition{11, 2}),
+		topRightRect:  newPlatformRect(common.RelativeRectPosition{12, 2}),
+		midLeftRect:   newPlatformRect(common.RelativeRectPosition{10, 3}),
+		midMiddleRect: newPlatformRect(common.RelativeRectPosition{11, 3}),
+		midRightRect:  newPlatformRect(common.RelativeRectPosition{12, 3}),
+	}
+	p, err := newPlatform(x, y, w, h, texture, icyPlatformRects)
+	p.material = materialIce
+	return p, err
+}
+
+// NewDoor creates a one tile wide solid platform from the mechanisms texture, locked doors show a keyhole
+func NewDoor(x, y, h int32, locked bool, texture *sdl.Texture) (Platform, error) {
+	if h < constants.TileDestHeight*2 {
+		return Platform{}, fmt.Errorf("door height value: %v must be higher (at least %v)", h, constants.TileDestHeight*2)
+	}
+	top := newPlatformRect(common.RelativeRectPosition{0, 0})
+	body := newPlatformRect(common.RelativeRectPosition{1, 0})
+	if locked {
+		body = newPlatformRect(common.RelativeRectPosition{6, 0})
+	}
+	return Platform{
+		X:           x,
+		Y:           y,
+		W:           constants.TileDestWidth,
+		H:           h,
+		texture:     texture,
+		sourceRects: platformRects{top, top, top, body, body, body},
+		decorations: []platformDecoration{},
+		collision:   CollisionSolid,
+		material:    materialGround,
+	}, nil
+}
+
+// NewLadderTop creates an invisible platform at the top of a ladder, characters can drop down through it
+func NewLadderTop(x, top, w int32) Platform {
+	h := constants.TileDestHeight / 4
+	return Platform{
+		X:           x,
+		Y:           top + h/2,
+		W:           w,
+		H:           h,
+		decorations: []platformDecoration{},
+		collision:   CollisionDropThrough,
+		material:    materialGround,
+	}
+}
+
 func newPlatform(x, y, w, h int32, texture *sdl.Texture, sourceRects platformRects) (Platform, error) {
 	if w < constants.TileDestWidth*3 {
 		return Platform{}, fmt.Errorf("width value: %v must be higher (at least %v)", w, constants.TileDestWidth*3)
 	}
-	return Platform{x, y, w, h, texture, sourceRects, []platformDecoration{}}, nil
+	return Platform{
+		X:           x,
+		Y:           y,
+		W:           w,
+		H:           h,
+		texture:     texture,
+		sourceRects: sourceRects,
+		decorations: []platformDecoration{},
+		collision:   CollisionOneWay,
+		material:    materialGround,
+	}, nil
+}
+
+func (p *Platform) SetCollisionMode(mode CollisionMode) {
+	p.collision = mode
+}
+
+// IsSolid returns true if characters cannot pass through the platform from below or from the sides
+func (p *Platform) IsSolid() bool {
+	return p.collision == CollisionSolid
+}
+
+// CanDropThrough returns true if characters can drop down through the platform
+func (p *Platform) CanDropThrough() bool {
+	return p.collision == CollisionDropThrough
+}
+
+// SetSlope makes the platform surface go up at the given angle (in degrees) towards the right or left side
+func (p *Platform) SetSlope(angle float64, risesRight bool) error {
+	if angle <= 0 || angle > 45 {
+		return fmt.Errorf("invalid slope angle: %v. Must be between 0 and 45", angle)
+	}
+	gradient := math.Tan(angle * math.Pi / 180)
+	if rise := int32(float64(p.W) * gradient); rise+constants.TileDestHeight > p.H {
+		return fmt.Errorf("platform height: %v is too low for the slope (at least %v)", p.H, rise+constants.TileDestHeight)
+	}
+	p.gradient = gradient
+	p.risesRight = risesRight
+	return nil
+}
+
+// IsSlope returns true if the platform surface is not flat
+func (p *Platform) IsSlope() bool {
+	return p.gradient != 0
+}
+
+// SurfaceY returns the y coordinate of the platform surface at given x
+func (p *Platform) SurfaceY(x int32) int32 {
+	top := p.Y - p.H/2
+	if p.gradient == 0 {
+		return top
+	}
+	left, right := p.X-p.W/2, p.X+p.W/2
+	if x < left {
+		x = left
+	}
+	if x > right {
+		x = right
+	}
+	if p.risesRight {
+		return top + int32(float64(right-x)*p.gradient)
+	}
+	return top + int32(float64(x-left)*p.gradient)
+}
+
+// SetPath makes the platform move along the path
+func (p *Platform) SetPath(path *Path) {
+	p.path = path
+}
+
+// Update moves the platform along its path, if it has any
+func (p *Platform) Update() {
+	p.DX, p.DY = 0, 0
+	if p.crumbleTime > 0 {
+		p.crumbleTime++
+		if p.crumbleTime > constants.CrumbleDelay+constants.CrumbleRespawnTime {
+			p.crumbleTime = 0
+		}
+	}
+	if p.path == nil {
+		return
+	}
+	p.DX, p.DY = p.path.update()
+	p.Shift(p.DX, p.DY)
+}
+
+// Trigger starts the platform moving if its path waits for a trigger
+func (p *Platform) Trigger() {
+	if p.path != nil {
+		p.path.trigger()
+	}
+}
+
+// StepOn notifies the platform that a character stands on it
+func (p *Platform) StepOn() {
+	p.Trigger()
+	if p.material == materialCrumbling && p.crumbleTime == 0 {
+		p.crumbleTime = 1
+	}
+}
+
+// IsCollapsed returns true if the crumbling platform is gone for now and cannot be stood on
+func (p *Platform) IsCollapsed() bool {
+	return p.crumbleTime > constants.CrumbleDelay
+}
+
+// SetHidden makes the platform disappear or show up again
+func (p *Platform) SetHidden(hidden bool) {
+	p.hidden = hidden
+}
+
+// Toggle shows the hidden platform or hides the visible one, opened doors are hidden
+func (p *Platform) Toggle() {
+	p.hidden = !p.hidden
+}
+
+// IsHidden returns true if the platform is not there for now
+func (p *Platform) IsHidden() bool {
+	return p.hidden
+}
+
+// IsBouncy returns true if the platform launches characters landing on it
+func (p *Platform) IsBouncy() bool {
+	return p.material == materialBouncy
+}
+
+// BounceImpulse returns the vertical speed given to characters landing on the platform
+func (p *Platform) BounceImpulse() float32 {
+	return p.bounceImpulse
+}
+
+// IsSlippery returns true if characters need time to speed up and slow down on the platform
+func (p *Platform) IsSlippery() bool {
+	return p.material == materialIce
+}
+
+// Shift moves the platform together with its decorations
+func (p *Platform) Shift(dx, dy int32) {
+	p.X += dx
+	p.Y += dy
+	for i := range p.decorations {
+		p.decorations[i].dstRect.X += dx
+		p.decorations[i].dstRect.Y += dy
+	}
 }
 
 func (p *Platform) AddUpperLeftDecoration(x, y int32) error {
@@ -104,31 +383,69 @@ func (p *Platform) addDecoration(srcRect *sdl.Rect, x, y int32) error {
 }
 
 func (p *Platform) Draw(renderer *sdl.Renderer) {
+	if p.IsCollapsed() || p.hidden {
+		return
+	}
+	// Crumbling platform shakes before it collapses
+	shakeX := int32(0)
+	if p.crumbleTime > 0 {
+		shakeX = int32(p.crumbleTime/4%3) - 1
+	}
+	if p.IsSlope() {
+		p.drawSlope(renderer)
+		return
+	}
 	// Top row
-	p.drawRow(renderer, p.sourceRects.topLeftRect, p.sourceRects.topMiddleRect, p.sourceRects.topRightRect, 0)
+	p.drawRow(renderer, p.sourceRects.topLeftRect, p.sourceRects.topMiddleRect, p.sourceRects.topRightRect, shakeX, 0)
 	// Other rows
 	for y := constants.TileDestHeight; y < p.H; y += constants.TileDestHeight - 1 {
-		p.drawRow(renderer, p.sourceRects.midLeftRect, p.sourceRects.midMiddleRect, p.sourceRects.midRightRect, y)
+		p.drawRow(renderer, p.sourceRects.midLeftRect, p.sourceRects.midMiddleRect, p.sourceRects.midRightRect, shakeX, y)
 	}
 	for _, pd := range p.decorations {
 		pd.draw(renderer)
 	}
 }
 
-func (p *Platform) drawRow(renderer *sdl.Renderer, tileLeftRect, tileMiddleRect, tileRightRect *sdl.Rect, y int32) {
-	err := renderer.Copy(p.texture, tileLeftRect, &sdl.Rect{p.X - p.W/2, p.Y - p.H/2 + y, constants.TileDestWidth, constants.TileDestHeight})
+// drawSlope draws the platform as columns of tiles going up the slope like stairs
+func (p *Platform) drawSlope(renderer *sdl.Renderer) {
+	tileDestWidth := constants.TileDestWidth
+	tileDestHeight := constants.TileDestHeight
+	for x := int32(0); x < p.W; x += tileDestWidth {
+		topRect, midRect := p.sourceRects.topMiddleRect, p.sourceRects.midMiddleRect
+		if x == 0 {
+			topRect, midRect = p.sourceRects.topLeftRect, p.sourceRects.midLeftRect
+		} else if x+tileDestWidth >= p.W {
+			topRect, midRect = p.sourceRects.topRightRect, p.sourceRects.midRightRect
+		}
+		columnX := p.X - p.W/2 + x
+		surfaceY := p.SurfaceY(columnX + tileDestWidth/2)
+		err := renderer.Copy(p.texture, topRect, &sdl.Rect{columnX, surfaceY, tileDestWidth, tileDestHeight})
+		if err != nil {
+			log.Fatalf("could not copy slope top texture: %v", err)
+		}
+		for y := surfaceY + tileDestHeight; y < p.Y+p.H/2; y += tileDestHeight - 1 {
+			err = renderer.Copy(p.texture, midRect, &sdl.Rect{columnX, y, tileDestWidth, tileDestHeight})
+			if err != nil {
+				log.Fatalf("could not copy slope middle texture: %v", err)
+			}
+		}
+	}
+}
+
+func (p *Platform) drawRow(renderer *sdl.Renderer, tileLeftRect, tileMiddleRect, tileRightRect *sdl.Rect, dx, y int32) {
+	err := renderer.Copy(p.texture, tileLeftRect, &sdl.Rect{p.X - p.W/2 + dx, p.Y - p.H/2 + y, constants.TileDestWidth, constants.TileDestHeight})
 	if err != nil {
 		log.Fatalf("could not copy platform left texture: %v", err)
 	}
 	tileDestWidth := constants.TileDestWidth
 	tileDestHeight := constants.TileDestHeight
 	for x := tileDestWidth; x < p.W-tileDestWidth; x += tileDestWidth {
-		err = renderer.Copy(p.texture, tileMiddleRect, &sdl.Rect{p.X - p.W/2 + x, p.Y - p.H/2 + y, tileDestWidth, tileDestHeight})
+		err = renderer.Copy(p.texture, tileMiddleRect, &sdl.Rect{p.X - p.W/2 + x + dx, p.Y - p.H/2 + y, tileDestWidth, tileDestHeight})
 		if err != nil {
 			log.Fatalf("could not copy platform middle texture: %v", err)
 		}
 	}
-	err = renderer.Copy(p.texture, tileRightRect, &sdl.Rect{p.X + p.W/2 - tileDestWidth, p.Y - p.H/2 + y, tileDestWidth, tileDestHeight})
+	err = renderer.Copy(p.texture, tileRightRect, &sdl.Rect{p.X + p.W/2 - tileDestWidth + dx, p.Y - p.H/2 + y, tileDestWidth, tileDestHeight})
 	if err != nil {
 		log.Fatalf("could not copy platform right texture: %v", err)
 	}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"simpleplatformer/constants"
+	"simpleplatformer/game/characters"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// score accumulates points, quick kills one after another raise the combo multiplier
+type score struct {
+	points    int
+	combo     int
+	comboTime int
+}
+
+func (s *score) add(points int) {
+	s.points += points
+}
+
+// addKill adds points for a killed enemy multiplied by the combo and returns the number of points added
+func (s *score) addKill(points int) int {
+	if s.comboTime > 0 && s.combo < constants.MaxComboMultiplier {
+		s.combo++
+	} else if s.comboTime == 0 {
+		s.combo = 1
+	}
+	s.comboTime = constants.ComboTime
+	points *= s.combo
+	s.points += points
+	return points
+}
+
+func (s *score) update() {
+	if s.comboTime > 0 {
+		s.comboTime--
+		if s.comboTime == 0 {
+			s.combo = 0
+		}
+	}
+}
+
+// scoreEvent shows the points given for something, floating up from where it happened
+type scoreEvent struct {
+	points int
+	x      int32
+	y      int32
+	time   int
+}
+
+func scoreForEnemy(e *characters.Character) int {
+	switch {
+	case e.IsEnemySlasher():
+		return constants.SlasherScore
+	case e.IsEnemySnake():
+		return constants.SnakeScore
+	case e.IsEnemyArcher():
+		return constants.ArcherScore
+	case e.IsEnemyBat():
+		return constants.BatScore
+	case e.IsEnemyBoss():
+		return constants.BossScore
+	}
+	return 0
+}
+
+// scoreEvent gives points for killed enemies and collected pickups
+func (g *Game) scoreEvent(e Event) {
+	switch e := e.(type) {
+	case CharacterDied:
+		v := e.Victim
+		if v == g.player || g.scoredKills[v] {
+			return
+		}
+		g.scoredKills[v] = true
+		points := g.score.addKill(scoreForEnemy(v))
+		g.scoreEvents = append(g.scoreEvents, &scoreEvent{points, v.X, v.Y - v.H, 0})
+	case PickupCollected:
+		p := e.Pickup
+		if points := p.Score(); points > 0 {
+			g.score.add(points)
+			g.scoreEvents = append(g.scoreEvents, &scoreEvent{points, p.X, p.Y, 0})
+		}
+	}
+}
+
+// updateScore counts down the combo time and moves the recent score events
+func (g *Game) updateScore() {
+	g.score.update()
+	events := []*scoreEvent{}
+	for _, se := range g.scoreEvents {
+		se.time++
+		se.y--
+		if se.time < constants.ScoreEventLength {
+			events = append(events, se)
+		}
+	}
+	g.scoreEvents = events
+}
+
+// drawHUD shows score, lives, equipped weapon, carried keys, combo multiplier and points of the recent score events
+func (g *Game) drawHUD(r *sdl.Renderer) {
+	f := openFont(20)
+	defer f.Close()
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	yellow := sdl.Color{R: 255, G: 220, B: 50, A: 255}
+	texts := []string{fmt.Sprintf("Score: %d", g.score.points), fmt.Sprintf("Lives: %d", g.lives)}
+	if len(g.weapons) > 1 {
+		texts = append(texts, "Weapon: "+g.weapon.String())
+	}
+	if keys := g.keyCount(); keys > 0 {
+		texts = append(texts, fmt.Sprintf("Keys: %d", keys))
+	}
+	if g.score.combo > 1 {
+		texts = append(texts, fmt.Sprintf("Combo x%d", g.score.combo))
+	}
+	for i, text := range texts {
+		if err := drawText(r, f, text, constants.TileDestWidth*3, constants.TileDestHeight/2+int32(i)*24, white); err != nil {
+			log.Fatalf("could not draw HUD: %v", err)
+		}
+	}
+	for _, se := range g.scoreEvents {
+		if err := drawText(r, f, fmt.Sprintf("+%d", se.points), se.x, se.y, yellow); err != nil {
+			log.Fatalf("could not draw score event: %v", err)
+		}
+	}
+}
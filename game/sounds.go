@@ -0,0 +1,56 @@
+package game
+
+import (
+	"simpleplatformer/audio"
+	"simpleplatformer/game/characters"
+)
+
+// musicTrack returns the track that should be playing now
+func (g *Game) musicTrack() string {
+	if g.bossFight != nil && g.bossFight.isLocked() {
+		return audio.BossMusic
+	}
+	return g.music
+}
+
+// soundForEvent returns the sound of the event and the character making it
+func soundForEvent(e Event) (audio.Sound, *characters.Character, bool) {
+	switch e := e.(type) {
+	case PlayerJumped:
+		return audio.Jump, e.Player, true
+	case EnemyJumped:
+		return audio.Jump, e.Enemy, true
+	case CharacterAttacked:
+		if e.Character.IsEnemySnake() {
+			return audio.Hiss, e.Character, true
+		}
+		return audio.Swoosh, e.Character, true
+	case CharacterHit:
+		return audio.Hit, e.Victim, true
+	case CharacterDied:
+		return audio.Death, e.Victim, true
+	case EnemyAlarmed:
+		return audio.Alarm, e.Enemy, true
+	case CharacterLanded:
+		return audio.Land, e.Character, true
+	case LadderStepped:
+		return audio.LadderStep, e.Character, true
+	case AttackBlocked:
+		return audio.Block, e.Blocker, true
+	}
+	return 0, nil, false
+}
+
+// playEventSound plays the sound of the event. Sounds of the other characters are heard
+// from where they are relative to the player, even off the screen, and follow them while they play.
+func (g *Game) playEventSound(e Event) {
+	s, c, ok := soundForEvent(e)
+	if !ok {
+		return
+	}
+	if c == g.player {
+		g.sounds.PlaySound(s)
+		return
+	}
+	g.sounds.PlaySoundAt(s, c)
+}
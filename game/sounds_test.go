@@ -0,0 +1,38 @@
+package game
+
+import (
+	"simpleplatformer/audio"
+	"simpleplatformer/game/characters"
+	"testing"
+)
+
+func TestSoundForEvent(t *testing.T) {
+	player := characters.NewPlayerCharacter(0, 0, nil, nil, nil)
+	snake := characters.NewSnake(0, 0, nil)
+	tests := []struct {
+		name      string
+		event     Event
+		sound     audio.Sound
+		character *characters.Character
+		ok        bool
+	}{
+		{"player jump", PlayerJumped{player}, audio.Jump, player, true},
+		{"enemy jump", EnemyJumped{snake}, audio.Jump, snake, true},
+		{"swing", CharacterAttacked{player}, audio.Swoosh, player, true},
+		{"snake bite", CharacterAttacked{snake}, audio.Hiss, snake, true},
+		{"hit", CharacterHit{player, snake}, audio.Hit, player, true},
+		{"death", CharacterDied{snake, player}, audio.Death, snake, true},
+		{"alarm", EnemyAlarmed{snake}, audio.Alarm, snake, true},
+		{"landing", CharacterLanded{player}, audio.Land, player, true},
+		{"ladder step", LadderStepped{player}, audio.LadderStep, player, true},
+		{"block", AttackBlocked{player, snake, false}, audio.Block, player, true},
+		{"pickup", PickupCollected{}, 0, nil, false},
+		{"level completed", LevelCompleted{}, 0, nil, false},
+	}
+	for _, tt := range tests {
+		s, c, ok := soundForEvent(tt.event)
+		if s != tt.sound || c != tt.character || ok != tt.ok {
+			t.Errorf("%v: got %v, %p, %v, want %v, %p, %v", tt.name, s, c, ok, tt.sound, tt.character, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"simpleplatformer/constants"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// LevelStats sums up how the player did in the level
+type LevelStats struct {
+	// Path is the level file, it identifies the level
+	Path    string
+	Name    string
+	Time    time.Duration
+	Kills   int
+	Enemies int
+	// Score is the total campaign score, LevelScore the points made in this level only
+	Score        int
+	LevelScore   int
+	Collected    int
+	Collectibles int
+}
+
+// Stats returns the statistics of the level played so far
+func (g *Game) Stats() LevelStats {
+	return LevelStats{
+		Path:         g.path,
+		Name:         g.name,
+		Time:         time.Since(g.startTime),
+		Kills:        len(g.scoredKills),
+		Enemies:      g.enemyCount,
+		Score:        g.score.points,
+		LevelScore:   g.score.points - g.startScore,
+		Collected:    g.collected,
+		Collectibles: g.collectibles,
+	}
+}
+
+// Draw shows the level complete screen with the stats
+func (s LevelStats) Draw(r *sdl.Renderer) {
+	title := openFont(60)
+	defer title.Close()
+	f := openFont(24)
+	defer f.Close()
+	orange := sdl.Color{R: 255, G: 100, B: 0, A: 255}
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	x := int32(constants.WindowWidth / 2)
+	y := int32(constants.WindowHeight / 6)
+	if err := drawText(r, title, s.Name+" complete", x, y, orange); err != nil {
+		log.Fatalf("could not draw level complete title: %v", err)
+	}
+	seconds := int(s.Time.Seconds())
+	lines := []string{
+		fmt.Sprintf("Time: %d:%02d", seconds/60, seconds%60),
+		fmt.Sprintf("Kills: %d/%d", s.Kills, s.Enemies),
+		fmt.Sprintf("Collectibles: %d/%d", s.Collected, s.Collectibles),
+		fmt.Sprintf("Score: %d (+%d)", s.Score, s.LevelScore),
+		"",
+		"Press space to continue",
+	}
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if err := drawText(r, f, line, x, y+100+int32(i)*36, white); err != nil {
+			log.Fatalf("could not draw level stats: %v", err)
+		}
+	}
+}
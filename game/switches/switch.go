@@ -0,0 +1,94 @@
+package switches
+
+import (
+	"fmt"
+	"log"
+	"simpleplatformer/constants"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+type Kind int
+
+const (
+	// Lever is flipped by the player every time he uses it
+	Lever Kind = iota
+	// PressurePlate is pressed by any character stepping on it and stays pressed
+	PressurePlate
+)
+
+// ParseKind returns switch kind of the given name
+func ParseKind(name string) (Kind, error) {
+	switch name {
+	case "lever":
+		return Lever, nil
+	case "plate":
+		return PressurePlate, nil
+	}
+	return 0, fmt.Errorf("unknown switch: %v", name)
+}
+
+func newSwitchRect(index int32) *sdl.Rect {
+	return &sdl.Rect{constants.TileSourceWidth * index, 0, constants.TileSourceWidth, constants.TileSourceHeight}
+}
+
+// Switch toggles the level entities it's wired to
+type Switch struct {
+	X    int32
+	Y    int32
+	Kind Kind
+	// Targets are ids of the platforms, ladders and doors toggled by the switch
+	Targets []string
+	on      bool
+	texture *sdl.Texture
+	offRect *sdl.Rect
+	onRect  *sdl.Rect
+}
+
+func NewSwitch(kind Kind, x, y int32, targets []string, texture *sdl.Texture) *Switch {
+	s := &Switch{X: x, Y: y, Kind: kind, Targets: targets, texture: texture}
+	switch kind {
+	case Lever:
+		s.offRect, s.onRect = newSwitchRect(2), newSwitchRect(3)
+	case PressurePlate:
+		s.offRect, s.onRect = newSwitchRect(4), newSwitchRect(5)
+	}
+	return s
+}
+
+// Activate flips the switch and returns true if its targets should be toggled
+func (s *Switch) Activate() bool {
+	if s.Kind == PressurePlate && s.on {
+		return false
+	}
+	s.on = !s.on
+	return true
+}
+
+// IsOn returns true if the lever is flipped or the plate is pressed
+func (s *Switch) IsOn() bool {
+	return s.on
+}
+
+// Touches returns true if the rectangle overlaps the switch
+func (s *Switch) Touches(r sdl.Rect) bool {
+	w, h := constants.TileDestWidth, constants.TileDestHeight
+	return r.X+r.W > s.X-w/2 && r.X < s.X+w/2 && r.Y+r.H > s.Y-h/2 && r.Y < s.Y+h/2
+}
+
+// Shift moves the switch together with the world
+func (s *Switch) Shift(dx, dy int32) {
+	s.X += dx
+	s.Y += dy
+}
+
+func (s *Switch) Draw(renderer *sdl.Renderer) {
+	w, h := constants.TileDestWidth, constants.TileDestHeight
+	src := s.offRect
+	if s.on {
+		src = s.onRect
+	}
+	if err := renderer.Copy(s.texture, src, &sdl.Rect{s.X - w/2, s.Y - h/2, w, h}); err != nil {
+		log.Fatalf("could not copy switch texture: %v", err)
+	}
+}
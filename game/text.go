@@ -0,0 +1,39 @@
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// openFont loads the game font, it's the caller's responsibility to close it
+func openFont(size int) *ttf.Font {
+	f, err := ttf.OpenFont("assets/test.ttf", size)
+	if err != nil {
+		log.Fatalf("could not load font: %v", err)
+	}
+	return f
+}
+
+// drawText draws the text horizontally centered at the position
+func drawText(r *sdl.Renderer, f *ttf.Font, text string, x, y int32, c sdl.Color) error {
+	s, err := f.RenderUTF8Solid(text, c)
+	if err != nil {
+		return fmt.Errorf("could not render text: %v", err)
+	}
+	defer s.Free()
+
+	t, err := r.CreateTextureFromSurface(s)
+	if err != nil {
+		return fmt.Errorf("could not create texture: %v", err)
+	}
+	defer t.Destroy()
+
+	_, _, w, h, err := t.Query()
+	if err != nil {
+		return fmt.Errorf("could not query texture: %v", err)
+	}
+	return r.Copy(t, nil, &sdl.Rect{x - w/2, y, w, h})
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"log"
+	"simpleplatformer/game/characters"
+	"simpleplatformer/game/pickups"
+	"simpleplatformer/save"
+)
+
+// weaponPickups are the weapons given by the pickups
+var weaponPickups = map[pickups.Kind]characters.Weapon{
+	pickups.Sword:          characters.Sword,
+	pickups.Spear:          characters.Spear,
+	pickups.ThrowingKnives: characters.ThrowingKnives,
+}
+
+// loadout returns the weapons the player has and the one equipped, the sword is always there
+func loadout(p save.Progress) ([]characters.Weapon, characters.Weapon) {
+	weapons := []characters.Weapon{characters.Sword}
+	for _, name := range p.Weapons {
+		w, err := characters.ParseWeapon(name)
+		if err != nil {
+			log.Printf("could not load weapon: %v", err)
+			continue
+		}
+		if w != characters.Sword {
+			weapons = append(weapons, w)
+		}
+	}
+	equipped, err := characters.ParseWeapon(p.Weapon)
+	if err != nil || !hasWeapon(weapons, equipped) {
+		equipped = characters.Sword
+	}
+	return weapons, equipped
+}
+
+func hasWeapon(weapons []characters.Weapon, w characters.Weapon) bool {
+	for _, o := range weapons {
+		if o == w {
+			return true
+		}
+	}
+	return false
+}
+
+// collectWeapon adds the weapon to the loadout and equips it, if the player does not have it yet
+func (g *Game) collectWeapon(w characters.Weapon) {
+	if hasWeapon(g.weapons, w) {
+		return
+	}
+	g.weapons = append(g.weapons, w)
+	g.weapon = w
+	g.player.Equip(w)
+}
+
+// switchWeapon equips the next weapon of the loadout
+func (g *Game) switchWeapon() {
+	for i, w := range g.weapons {
+		if w == g.weapon {
+			g.weapon = g.weapons[(i+1)%len(g.weapons)]
+			break
+		}
+	}
+	g.player.Equip(g.weapon)
+}
+
+// weaponNames returns names of the weapons in the loadout, as they are kept in the progress
+func (g *Game) weaponNames() []string {
+	names := []string{}
+	for _, w := range g.weapons {
+		names = append(names, w.String())
+	}
+	return names
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"simpleplatformer/common"
+	"simpleplatformer/constants"
+	"simpleplatformer/save"
+	"sort"
+	"strings"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// pendingScore is a score waiting for the player name before it gets to a high score table
+type pendingScore struct {
+	// level is the path of the level file, empty for the overall table
+	level     string
+	levelName string
+	score     int
+}
+
+// nameEntry lets the player enter his initials letter by letter
+type nameEntry struct {
+	letters  []byte
+	position int
+	pending  []pendingScore
+	// next is the state to go to once the name is entered
+	next common.GeneralState
+}
+
+func newNameEntry(lastName string, pending []pendingScore, next common.GeneralState) *nameEntry {
+	letters := []byte(strings.Repeat("A", constants.NameLength))
+	copy(letters, lastName)
+	return &nameEntry{letters, 0, pending, next}
+}
+
+// handleKey changes the selected letter or moves to another one, it returns true when the name is confirmed
+func (ne *nameEntry) handleKey(key sdl.Keycode) bool {
+	switch key {
+	case sdl.K_UP:
+		ne.letters[ne.position] = 'A' + (ne.letters[ne.position]-'A'+1)%26
+	case sdl.K_DOWN:
+		ne.letters[ne.position] = 'A' + (ne.letters[ne.position]-'A'+25)%26
+	case sdl.K_LEFT:
+		if ne.position > 0 {
+			ne.position--
+		}
+	case sdl.K_RIGHT:
+		if ne.position < len(ne.letters)-1 {
+			ne.position++
+		}
+	case sdl.K_SPACE, sdl.K_RETURN:
+		return true
+	}
+	return false
+}
+
+func (ne *nameEntry) name() string {
+	return string(ne.letters)
+}
+
+// submit puts the pending scores to the high score tables under the entered name
+func (ne *nameEntry) submit(h *save.HighScores) {
+	for _, ps := range ne.pending {
+		if ps.level == "" {
+			h.AddOverall(ne.name(), ps.score)
+		} else {
+			h.AddLevel(ps.level, ps.levelName, ne.name(), ps.score)
+		}
+	}
+}
+
+func (ne *nameEntry) draw(r *sdl.Renderer) {
+	orange := sdl.Color{R: 255, G: 100, B: 0, A: 255}
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	yellow := sdl.Color{R: 255, G: 220, B: 50, A: 255}
+	y := int32(constants.WindowHeight / 6)
+	if err := drawTextAt(r, "New high score!", 60, y, orange); err != nil {
+		log.Fatal(err)
+	}
+	y += 90
+	for _, ps := range ne.pending {
+		text := fmt.Sprintf("Campaign: %d", ps.score)
+		if ps.level != "" {
+			text = fmt.Sprintf("%v: %d", ps.levelName, ps.score)
+		}
+		if err := drawTextAt(r, text, 24, y, white); err != nil {
+			log.Fatal(err)
+		}
+		y += 32
+	}
+	letters := []string{}
+	for i, l := range ne.letters {
+		if i == ne.position {
+			letters = append(letters, "["+string(l)+"]")
+		} else {
+			letters = append(letters, " "+string(l)+" ")
+		}
+	}
+	if err := drawTextAt(r, strings.Join(letters, ""), 48, y+20, yellow); err != nil {
+		log.Fatal(err)
+	}
+	if err := drawTextAt(r, "Up/Down: change letter   Left/Right: move   Space: confirm", 16, y+100, white); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// leaderboardPages returns the paths of levels with a high score table, the overall table comes first as an empty path
+func leaderboardPages(h *save.HighScores) []string {
+	levels := []string{}
+	for path := range h.Levels {
+		levels = append(levels, path)
+	}
+	sort.Strings(levels)
+	return append([]string{""}, levels...)
+}
+
+// displayLeaderboard shows one of the high score tables
+func displayLeaderboard(r *sdl.Renderer, h *save.HighScores, page int) {
+	orange := sdl.Color{R: 255, G: 100, B: 0, A: 255}
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	pages := leaderboardPages(h)
+	title, entries := "Best campaigns", h.Overall
+	if level := pages[page]; level != "" {
+		title, entries = h.Levels[level].Name, h.Levels[level].Entries
+	}
+	y := int32(constants.WindowHeight / 10)
+	if err := drawTextAt(r, title, 48, y, orange); err != nil {
+		log.Fatal(err)
+	}
+	y += 70
+	if len(entries) == 0 {
+		if err := drawTextAt(r, "No scores yet", 24, y, white); err != nil {
+			log.Fatal(err)
+		}
+	}
+	for i, e := range entries {
+		if err := drawTextAt(r, fmt.Sprintf("%d. %v %8d", i+1, e.Name, e.Score), 24, y, white); err != nil {
+			log.Fatal(err)
+		}
+		y += 30
+	}
+	hint := fmt.Sprintf("Left/Right: table %d/%d   Space: back", page+1, len(pages))
+	if err := drawTextAt(r, hint, 16, constants.WindowHeight-40, white); err != nil {
+		log.Fatal(err)
+	}
+}
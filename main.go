@@ -13,10 +13,13 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"simpleplatformer/audio"
 	"simpleplatformer/common"
 	"simpleplatformer/constants"
 	"simpleplatformer/game"
+	"simpleplatformer/game/characters"
 	"simpleplatformer/game/platforms"
+	"simpleplatformer/save"
 	"time"
 
 	"github.com/veandco/go-sdl2/img"
@@ -52,20 +55,46 @@ func main() {
 	}
 	defer renderer.Destroy()
 	sdl.SetHint(sdl.HINT_RENDER_SCALE_QUALITY, "1")
+	// Keeps the game scaled to the whole screen in fullscreen mode
+	renderer.SetLogicalSize(constants.WindowWidth, constants.WindowHeight)
 
-	// explosionBytes, audioSpec := sdl.LoadWAV("balloons/explode.wav")
-	// if audioSpec == nil {
-	// 	log.Println(sdl.GetError())
-	// 	return
-	// }
-	// audioID, err := sdl.OpenAudioDevice("", false, audioSpec, nil, 0)
-	// if err != nil {
-	// 	panic(err)
-	// }
-	// defer sdl.CloseAudioDevice(audioID)
-	// defer sdl.FreeWAV(explosionBytes)
+	physics, err := characters.LoadPhysics(constants.PhysicsPath)
+	if os.IsNotExist(err) {
+		log.Printf("no physics config, using defaults")
+	} else if err != nil {
+		log.Fatalf("could not load physics: %v", err)
+	}
+	characters.SetPhysics(physics)
+
+	var sounds audio.Backend
+	sounds, err = audio.NewMixerBackend(constants.SoundsDir, constants.MusicDir)
+	if err != nil {
+		log.Printf("could not initialize audio, playing without sound: %v", err)
+		sounds = audio.NullBackend{}
+	}
+	defer sounds.Close()
 
-	// audioState := audioState{explosionBytes, audioID, audioSpec}
+	settings, err := save.LoadSettings()
+	if err != nil {
+		log.Printf("could not load settings, using defaults: %v", err)
+	}
+	applySettings(window, sounds, settings)
+	slots, err := save.LoadSlots()
+	if err != nil {
+		log.Fatalf("could not load save slots: %v", err)
+	}
+	highScores, err := save.LoadHighScores()
+	if err != nil {
+		log.Printf("could not load high scores: %v", err)
+	}
+	var entry *nameEntry
+	var pause pauseMenu
+	// lastName is offered when the player gets another high score
+	lastName := ""
+	leaderboardPage := 0
+	selectedSlot := 0
+	// startLevel is the level a new campaign starts from, only unlocked levels can be chosen
+	startLevel := 0
 
 	// go func() {
 	// 	sdl.Delay(5000)
@@ -75,6 +104,9 @@ func main() {
 
 	var elapsedTime float32
 	var g *game.Game
+	var campaign *game.Campaign
+	var slot *save.Slot
+	var stats game.LevelStats
 
 	renderer.SetDrawColor(uint8(66), uint8(135), uint8(245), uint8(0))
 
@@ -95,17 +127,97 @@ func main() {
 	}
 	defer texSwoosh.Destroy()
 
+	texProjectiles, err := img.LoadTexture(renderer, "assets/projectiles.png")
+	if err != nil {
+		log.Fatalf("could not load projectiles texture: %v", err)
+	}
+	defer texProjectiles.Destroy()
+
+	texBat, err := img.LoadTexture(renderer, "assets/bat.png")
+	if err != nil {
+		log.Fatalf("could not load bat texture: %v", err)
+	}
+	defer texBat.Destroy()
+
+	texHazards, err := img.LoadTexture(renderer, "assets/hazards.png")
+	if err != nil {
+		log.Fatalf("could not load hazards texture: %v", err)
+	}
+	defer texHazards.Destroy()
+
+	texPickups, err := img.LoadTexture(renderer, "assets/pickups.png")
+	if err != nil {
+		log.Fatalf("could not load pickups texture: %v", err)
+	}
+	defer texPickups.Destroy()
+
+	texMechanisms, err := img.LoadTexture(renderer, "assets/mechanisms.png")
+	if err != nil {
+		log.Fatalf("could not load mechanisms texture: %v", err)
+	}
+	defer texMechanisms.Destroy()
+
+	texClimbables, err := img.LoadTexture(renderer, "assets/climbables.png")
+	if err != nil {
+		log.Fatalf("could not load climbables texture: %v", err)
+	}
+	defer texClimbables.Destroy()
+
+	textures := game.Textures{
+		Characters:  texCharacters,
+		Background:  texBackground,
+		Swoosh:      texSwoosh,
+		Projectiles: texProjectiles,
+		Bat:         texBat,
+		Hazards:     texHazards,
+		Pickups:     texPickups,
+		Mechanisms:  texMechanisms,
+		Climbables:  texClimbables,
+	}
+
 	keyState := sdl.GetKeyboardState()
 
 	running := true
 	for running {
 		frameStart := time.Now()
+		sounds.Update()
 		if state == common.Start {
+			sounds.PlayMusic(audio.TitleMusic)
 			for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
 				switch e := event.(type) {
 				case *sdl.KeyboardEvent:
-					if sdl.K_SPACE == e.Keysym.Sym && e.State == sdl.PRESSED {
-						g = game.NewGame(texCharacters, texBackground, texSwoosh)
+					if e.State != sdl.PRESSED {
+						break
+					}
+					switch e.Keysym.Sym {
+					case sdl.K_UP:
+						selectedSlot = (selectedSlot + len(slots) - 1) % len(slots)
+						startLevel = 0
+					case sdl.K_DOWN:
+						selectedSlot = (selectedSlot + 1) % len(slots)
+						startLevel = 0
+					case sdl.K_LEFT:
+						if startLevel > 0 {
+							startLevel--
+						}
+					case sdl.K_RIGHT:
+						if startLevel < slots[selectedSlot].Data.UnlockedLevels-1 {
+							startLevel++
+						}
+					case sdl.K_F11:
+						settings.Fullscreen = !settings.Fullscreen
+						applySettings(window, sounds, settings)
+						saveSettings(settings)
+					case sdl.K_l:
+						leaderboardPage = 0
+						state = common.Leaderboard
+					case sdl.K_SPACE:
+						if slots[selectedSlot].ReadOnly {
+							break
+						}
+						slot = slots[selectedSlot]
+						campaign = startCampaign(slot, startLevel)
+						g = campaign.StartLevel(textures, sounds)
 						state = common.Play
 					}
 				case *sdl.QuitEvent:
@@ -117,15 +229,51 @@ func main() {
 			renderer.Clear()
 
 			displayTitle(renderer, texBackground)
+			displaySlots(renderer, slots, selectedSlot, startLevel)
 
 			renderer.Present()
-		} else if state == common.Over {
+		} else if state == common.Leaderboard {
+			sounds.PlayMusic(audio.TitleMusic)
 			for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
 				switch e := event.(type) {
 				case *sdl.KeyboardEvent:
-					if sdl.K_SPACE == e.Keysym.Sym && e.State == sdl.PRESSED {
-						g = game.NewGame(texCharacters, texBackground, texSwoosh)
-						state = common.Play
+					if e.State != sdl.PRESSED {
+						break
+					}
+					pageCount := len(leaderboardPages(highScores))
+					switch e.Keysym.Sym {
+					case sdl.K_LEFT:
+						leaderboardPage = (leaderboardPage + pageCount - 1) % pageCount
+					case sdl.K_RIGHT:
+						leaderboardPage = (leaderboardPage + 1) % pageCount
+					case sdl.K_SPACE, sdl.K_ESCAPE:
+						state = common.Start
+					}
+				case *sdl.QuitEvent:
+					println("Quit")
+					running = false
+					break
+				}
+			}
+			renderer.Clear()
+
+			displayLeaderboard(renderer, highScores, leaderboardPage)
+
+			renderer.Present()
+		} else if state == common.NameEntry {
+			if entry.next == common.Over {
+				sounds.PlayMusic(audio.GameOverMusic)
+			}
+			for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+				switch e := event.(type) {
+				case *sdl.KeyboardEvent:
+					if e.State == sdl.PRESSED && e.Repeat == 0 && entry.handleKey(e.Keysym.Sym) {
+						entry.submit(highScores)
+						if err := highScores.Save(); err != nil {
+							log.Printf("could not save high scores: %v", err)
+						}
+						lastName = entry.name()
+						state = entry.next
 					}
 				case *sdl.QuitEvent:
 					println("Quit")
@@ -135,11 +283,92 @@ func main() {
 			}
 			renderer.Clear()
 
-			err = drawText(renderer, "Game over")
+			entry.draw(renderer)
+
+			renderer.Present()
+		} else if state == common.LevelComplete {
+			for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+				switch e := event.(type) {
+				case *sdl.KeyboardEvent:
+					// Space held down since playing the level must not skip the stats
+					if sdl.K_SPACE == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+						if campaign.IsFinished() {
+							state = common.CampaignComplete
+						} else {
+							g = campaign.StartLevel(textures, sounds)
+							state = common.Play
+						}
+					}
+				case *sdl.QuitEvent:
+					println("Quit")
+					running = false
+					break
+				}
+			}
+			renderer.Clear()
+
+			stats.Draw(renderer)
+
+			renderer.Present()
+		} else if state == common.Over || state == common.CampaignComplete {
+			if state == common.Over {
+				sounds.PlayMusic(audio.GameOverMusic)
+			} else {
+				sounds.PlayMusic(audio.TitleMusic)
+			}
+			for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+				switch e := event.(type) {
+				case *sdl.KeyboardEvent:
+					if sdl.K_SPACE == e.Keysym.Sym && e.State == sdl.PRESSED && e.Repeat == 0 {
+						state = common.Start
+					}
+				case *sdl.QuitEvent:
+					println("Quit")
+					running = false
+					break
+				}
+			}
+			renderer.Clear()
+
+			text := "Game over"
+			if state == common.CampaignComplete {
+				text = fmt.Sprintf("The end! Score: %d", campaign.Progress.Score)
+			}
+			err = drawText(renderer, text)
 			if err != nil {
 				log.Fatal(err)
 			}
 
+			renderer.Present()
+		} else if state == common.Paused {
+			for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+				switch e := event.(type) {
+				case *sdl.KeyboardEvent:
+					if e.State != sdl.PRESSED || e.Repeat != 0 {
+						break
+					}
+					switch pause.handleKey(e.Keysym.Sym, &settings, sounds) {
+					case pauseResume:
+						state = common.Play
+					case pauseQuit:
+						// Progress made in the level is lost, the slot keeps the one saved after the last completed level
+						state = common.Start
+					}
+					if state != common.Paused {
+						sounds.SetDucked(false)
+						saveSettings(settings)
+					}
+				case *sdl.QuitEvent:
+					println("Quit")
+					running = false
+					break
+				}
+			}
+			renderer.Clear()
+
+			g.Draw(renderer)
+			pause.draw(renderer, settings)
+
 			renderer.Present()
 		} else if state == common.Play {
 			var newState common.GeneralState
@@ -147,6 +376,33 @@ func main() {
 			if !running {
 				break
 			}
+			pending := []pendingScore{}
+			if newState == common.LevelComplete {
+				stats = campaign.CompleteLevel(g)
+				result := save.LevelResult{Path: stats.Path, Time: stats.Time, Score: stats.LevelScore, Collected: stats.Collected}
+				slot.CompleteLevel(result, campaign.Progress, campaign.LevelCount())
+				saveSlot(slot)
+				if highScores.QualifiesLevel(stats.Path, stats.LevelScore) {
+					pending = append(pending, pendingScore{stats.Path, stats.Name, stats.LevelScore})
+				}
+				if campaign.IsFinished() && highScores.QualifiesOverall(stats.Score) {
+					pending = append(pending, pendingScore{score: stats.Score})
+				}
+			} else if newState == common.Over {
+				slot.GameOver(g.Score())
+				saveSlot(slot)
+				if highScores.QualifiesOverall(g.Score()) {
+					pending = append(pending, pendingScore{score: g.Score()})
+				}
+			}
+			if len(pending) > 0 {
+				entry = newNameEntry(lastName, pending, newState)
+				newState = common.NameEntry
+			}
+			if newState == common.Paused {
+				pause = pauseMenu{}
+				sounds.SetDucked(true)
+			}
 			state = newState
 		}
 		elapsedTime = float32(time.Since(frameStart).Seconds() * 1000)
@@ -198,14 +454,78 @@ func displayTitle(r *sdl.Renderer, texBackground *sdl.Texture) {
 	}
 }
 
+// startCampaign continues the campaign saved in the slot or starts a new one from the given level
+func startCampaign(slot *save.Slot, startLevel int) *game.Campaign {
+	campaign := game.NewCampaign(constants.CampaignPath)
+	if slot.HasProgress() && slot.Data.Progress.Level < campaign.LevelCount() {
+		campaign.Progress = slot.Data.Progress
+	} else if startLevel < campaign.LevelCount() {
+		campaign.Progress.Level = startLevel
+	}
+	return campaign
+}
+
+func saveSlot(slot *save.Slot) {
+	if err := slot.Save(); err != nil {
+		log.Printf("could not save the game: %v", err)
+	}
+}
+
+func saveSettings(settings save.Settings) {
+	if err := settings.Save(); err != nil {
+		log.Printf("could not save settings: %v", err)
+	}
+}
+
+func applySettings(window *sdl.Window, sounds audio.Backend, settings save.Settings) {
+	sounds.SetVolume(settings.MusicVolume, settings.SoundVolume)
+	var flags uint32
+	if settings.Fullscreen {
+		flags = sdl.WINDOW_FULLSCREEN_DESKTOP
+	}
+	if err := window.SetFullscreen(flags); err != nil {
+		log.Printf("could not switch fullscreen mode: %v", err)
+	}
+}
+
+// displaySlots lists the save slots under the title, the selected one is highlighted
+func displaySlots(r *sdl.Renderer, slots []*save.Slot, selected, startLevel int) {
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	yellow := sdl.Color{R: 255, G: 220, B: 50, A: 255}
+	y := int32(constants.WindowHeight/2 + 40)
+	for i, s := range slots {
+		text, c := s.Describe(), white
+		if i == selected {
+			text, c = "> "+text+" <", yellow
+		}
+		if err := drawTextAt(r, text, 20, y, c); err != nil {
+			log.Fatal(err)
+		}
+		y += 26
+	}
+	s := slots[selected]
+	hint := "Space: play   Up/Down: choose slot   L: leaderboard   F11: fullscreen"
+	if !s.HasProgress() && s.Data.UnlockedLevels > 1 {
+		hint = fmt.Sprintf("Left/Right: start at level %d   ", startLevel+1) + hint
+	}
+	if err := drawTextAt(r, hint, 16, y+10, white); err != nil {
+		log.Fatal(err)
+	}
+}
+
 func drawText(r *sdl.Renderer, text string) error {
-	f, err := ttf.OpenFont("assets/test.ttf", 60)
+	c := sdl.Color{R: 255, G: 100, B: 0, A: 255}
+	return drawTextAt(r, text, 60, -1, c)
+}
+
+// drawTextAt draws horizontally centered text at y, negative y centers it vertically too
+func drawTextAt(r *sdl.Renderer, text string, size int, y int32, c sdl.Color) error {
+	f, err := ttf.OpenFont("assets/test.ttf", size)
 	if err != nil {
 		return fmt.Errorf("could not load font: %v", err)
 	}
 	defer f.Close()
 
-	c := sdl.Color{R: 255, G: 100, B: 0, A: 255}
 	s, err := f.RenderUTF8Solid(text, c)
 	if err != nil {
 		return fmt.Errorf("could not render title: %v", err)
@@ -222,7 +542,10 @@ func drawText(r *sdl.Renderer, text string) error {
 	if err != nil {
 		return fmt.Errorf("could not query texture: %v", err)
 	}
-	dstRect := &sdl.Rect{constants.WindowWidth/2 - w/2, constants.WindowHeight/2 - h/2, w, h}
+	if y < 0 {
+		y = constants.WindowHeight/2 - h/2
+	}
+	dstRect := &sdl.Rect{constants.WindowWidth/2 - w/2, y, w, h}
 	if err := r.Copy(t, nil, dstRect); err != nil {
 		return fmt.Errorf("could not copy texture: %v", err)
 	}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"simpleplatformer/audio"
+	"simpleplatformer/constants"
+	"simpleplatformer/save"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+type pauseAction int
+
+const (
+	pauseNone pauseAction = iota
+	pauseResume
+	pauseQuit
+)
+
+const (
+	pauseItemResume = iota
+	pauseItemMusic
+	pauseItemSound
+	pauseItemQuit
+	pauseItemCount
+)
+
+// pauseMenu is shown over the game when the player presses escape, it changes the volume settings
+type pauseMenu struct {
+	selected int
+}
+
+// handleKey moves the selection or changes the selected volume, it returns what the player chose to do
+func (pm *pauseMenu) handleKey(key sdl.Keycode, settings *save.Settings, sounds audio.Backend) pauseAction {
+	switch key {
+	case sdl.K_ESCAPE:
+		return pauseResume
+	case sdl.K_UP:
+		pm.selected = (pm.selected + pauseItemCount - 1) % pauseItemCount
+	case sdl.K_DOWN:
+		pm.selected = (pm.selected + 1) % pauseItemCount
+	case sdl.K_LEFT, sdl.K_RIGHT:
+		change := 1
+		if key == sdl.K_LEFT {
+			change = -1
+		}
+		if pm.selected == pauseItemMusic {
+			settings.MusicVolume = clampVolume(settings.MusicVolume + change)
+		} else if pm.selected == pauseItemSound {
+			settings.SoundVolume = clampVolume(settings.SoundVolume + change)
+		}
+		sounds.SetVolume(settings.MusicVolume, settings.SoundVolume)
+	case sdl.K_SPACE, sdl.K_RETURN:
+		if pm.selected == pauseItemResume {
+			return pauseResume
+		} else if pm.selected == pauseItemQuit {
+			return pauseQuit
+		}
+	}
+	return pauseNone
+}
+
+func clampVolume(v int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > constants.VolumeSteps {
+		return constants.VolumeSteps
+	}
+	return v
+}
+
+func (pm *pauseMenu) draw(r *sdl.Renderer, settings save.Settings) {
+	red, green, blue, alpha, err := r.GetDrawColor()
+	if err != nil {
+		log.Fatalf("could not draw pause menu: %v", err)
+	}
+	r.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	r.SetDrawColor(0, 0, 0, 160)
+	r.FillRect(&sdl.Rect{0, 0, constants.WindowWidth, constants.WindowHeight})
+	r.SetDrawColor(red, green, blue, alpha)
+
+	orange := sdl.Color{R: 255, G: 100, B: 0, A: 255}
+	white := sdl.Color{R: 255, G: 255, B: 255, A: 255}
+	yellow := sdl.Color{R: 255, G: 220, B: 50, A: 255}
+	y := int32(constants.WindowHeight / 5)
+	if err := drawTextAt(r, "Paused", 60, y, orange); err != nil {
+		log.Fatal(err)
+	}
+	items := []string{
+		"Resume",
+		fmt.Sprintf("Music volume: < %d >", settings.MusicVolume),
+		fmt.Sprintf("Sound volume: < %d >", settings.SoundVolume),
+		"Quit to title",
+	}
+	y += 100
+	for i, item := range items {
+		c := white
+		if i == pm.selected {
+			item, c = "> "+item+" <", yellow
+		}
+		if err := drawTextAt(r, item, 28, y, c); err != nil {
+			log.Fatal(err)
+		}
+		y += 40
+	}
+}
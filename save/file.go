@@ -0,0 +1,118 @@
+package save
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"simpleplatformer/constants"
+)
+
+// ErrCorrupted is returned when the save file content does not match its checksum
+var ErrCorrupted = errors.New("save file is corrupted")
+
+// ErrNewerVersion is returned when the save file was written by a newer version of the game.
+// Such files are never written, so that playing an older version does not destroy them.
+var ErrNewerVersion = errors.New("save file was written by a newer version of the game")
+
+// envelope is the content of a save file, checksum protects data from corruption and manual edits
+type envelope struct {
+	Version  int             `json:"version"`
+	Checksum string          `json:"checksum"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// migration upgrades the decoded save data by one version
+type migration func(data map[string]interface{})
+
+// Dir returns the directory save files are stored in, it's created if it does not exist
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find config directory: %v", err)
+	}
+	dir := filepath.Join(configDir, constants.SaveDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create save directory: %v", err)
+	}
+	return dir, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// write stores v in the file, replacing it only after the new content is fully written
+func write(name string, version int, v interface{}) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("could not encode save data: %v", err)
+	}
+	content, err := json.Marshal(envelope{version, checksum(data), data})
+	if err != nil {
+		return fmt.Errorf("could not encode save file: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path+".tmp", content, 0644); err != nil {
+		return fmt.Errorf("could not write save file: %v", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("could not replace save file: %v", err)
+	}
+	return nil
+}
+
+// read loads v from the file, data of older versions is migrated to the given one.
+// It returns an error satisfying os.IsNotExist if there is no such file.
+func read(name string, version int, migrations []migration, v interface{}) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	content, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	e := envelope{}
+	if err := json.Unmarshal(content, &e); err != nil {
+		return ErrCorrupted
+	}
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, e.Data); err != nil || checksum(compacted.Bytes()) != e.Checksum {
+		return ErrCorrupted
+	}
+	// Versions start at 1, a file without one cannot be migrated
+	if e.Version < 1 {
+		return ErrCorrupted
+	}
+	if e.Version > version {
+		return ErrNewerVersion
+	}
+	if e.Version < version {
+		data := map[string]interface{}{}
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return ErrCorrupted
+		}
+		for ; e.Version < version; e.Version++ {
+			// Versions start at 1, the first migration upgrades from 1 to 2
+			migrations[e.Version-1](data)
+		}
+		if e.Data, err = json.Marshal(data); err != nil {
+			return fmt.Errorf("could not encode migrated save data: %v", err)
+		}
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return ErrCorrupted
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package save
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// useTempConfigDir points the config directory at a fresh temporary one for the test
+func useTempConfigDir(t *testing.T) string {
+	tmp, err := ioutil.TempDir("", "save")
+	if err != nil {
+		t.Fatal(err)
+	}
+	home, config := os.Getenv("HOME"), os.Getenv("XDG_CONFIG_HOME")
+	os.Setenv("HOME", tmp)
+	os.Setenv("XDG_CONFIG_HOME", tmp)
+	t.Cleanup(func() {
+		os.Setenv("HOME", home)
+		os.Setenv("XDG_CONFIG_HOME", config)
+		os.RemoveAll(tmp)
+	})
+	dir, err := Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// writeEnvelope stores data in the named file as it's written by the given version
+func writeEnvelope(t *testing.T, dir, name string, version int, data string) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatal(err)
+	}
+	compacted, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, name, envelope{version, checksum(compacted), compacted})
+}
+
+func writeFile(t *testing.T, dir, name string, v interface{}) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	useTempConfigDir(t)
+	if err := write("test.json", 1, Settings{Fullscreen: true}); err != nil {
+		t.Fatal(err)
+	}
+	s := Settings{}
+	if err := read("test.json", 1, nil, &s); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Fullscreen {
+		t.Error("got windowed settings, want fullscreen")
+	}
+}
+
+func TestReadChecksumMismatch(t *testing.T) {
+	dir := useTempConfigDir(t)
+	writeFile(t, dir, "test.json", envelope{1, checksum([]byte(`{"fullscreen":false}`)), json.RawMessage(`{"fullscreen":true}`)})
+	if err := read("test.json", 1, nil, &Settings{}); err != ErrCorrupted {
+		t.Errorf("got error %v, want %v", err, ErrCorrupted)
+	}
+}
+
+func TestReadCorruptedEnvelope(t *testing.T) {
+	dir := useTempConfigDir(t)
+	if err := ioutil.WriteFile(filepath.Join(dir, "test.json"), []byte(`{"version":1,"check`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := read("test.json", 1, nil, &Settings{}); err != ErrCorrupted {
+		t.Errorf("got error %v, want %v", err, ErrCorrupted)
+	}
+}
+
+func TestReadInvalidVersion(t *testing.T) {
+	dir := useTempConfigDir(t)
+	for _, version := range []int{-1, 0} {
+		writeEnvelope(t, dir, "test.json", version, `{"fullscreen":true}`)
+		if err := read("test.json", 2, []migration{func(map[string]interface{}) {}}, &Settings{}); err != ErrCorrupted {
+			t.Errorf("version %v: got error %v, want %v", version, err, ErrCorrupted)
+		}
+	}
+}
+
+func TestReadMigration(t *testing.T) {
+	dir := useTempConfigDir(t)
+	writeEnvelope(t, dir, "test.json", 1, `{}`)
+	migrations := []migration{
+		func(data map[string]interface{}) { data["fullscreen"] = true },
+	}
+	s := Settings{}
+	if err := read("test.json", 2, migrations, &s); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Fullscreen {
+		t.Error("migration was not applied")
+	}
+}
+
+func TestReadMissing(t *testing.T) {
+	useTempConfigDir(t)
+	if err := read("test.json", 1, nil, &Settings{}); !os.IsNotExist(err) {
+		t.Errorf("got error %v, want a missing file", err)
+	}
+}
+
+func TestReadNewerVersion(t *testing.T) {
+	dir := useTempConfigDir(t)
+	writeEnvelope(t, dir, "test.json", 2, `{"fullscreen":true}`)
+	if err := read("test.json", 1, nil, &Settings{}); err != ErrNewerVersion {
+		t.Errorf("got error %v, want %v", err, ErrNewerVersion)
+	}
+}
+
+func TestNewerSettingsNotOverwritten(t *testing.T) {
+	dir := useTempConfigDir(t)
+	writeEnvelope(t, dir, settingsFileName, settingsVersion+1, `{"fullscreen":true}`)
+	s, err := LoadSettings()
+	if err != ErrNewerVersion {
+		t.Errorf("got error %v, want %v", err, ErrNewerVersion)
+	}
+	if err := s.Save(); err != ErrNewerVersion {
+		t.Errorf("got save error %v, want %v", err, ErrNewerVersion)
+	}
+	e := envelope{}
+	content, err := ioutil.ReadFile(filepath.Join(dir, settingsFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(content, &e); err != nil || e.Version != settingsVersion+1 {
+		t.Errorf("settings file was overwritten: %s", content)
+	}
+}
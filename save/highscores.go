@@ -0,0 +1,107 @@
+package save
+
+import (
+	"os"
+	"simpleplatformer/constants"
+)
+
+// highScoresVersion is the current version of the high scores file format
+const highScoresVersion = 1
+
+// highScoresMigrations upgrade older high scores files, see migration
+var highScoresMigrations = []migration{}
+
+const highScoresFileName = "highscores.json"
+
+// ScoreEntry is a single line of a high score table
+type ScoreEntry struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+// LevelScores is the high score table of a single level
+type LevelScores struct {
+	// Name is the level name as shown to the player
+	Name    string       `json:"name"`
+	Entries []ScoreEntry `json:"entries"`
+}
+
+// HighScores are the best campaign scores and the best scores made in every level, shared by all the slots
+type HighScores struct {
+	Overall []ScoreEntry `json:"overall"`
+	// Levels are identified by the path of the level file
+	Levels map[string]*LevelScores `json:"levels"`
+	// readOnly tables were saved by a newer version of the game, new entries are not written
+	readOnly bool
+}
+
+// LoadHighScores reads the high score tables, empty ones are returned together with the error if they cannot be read
+func LoadHighScores() (*HighScores, error) {
+	h := &HighScores{Overall: []ScoreEntry{}, Levels: map[string]*LevelScores{}}
+	err := read(highScoresFileName, highScoresVersion, highScoresMigrations, h)
+	if err != nil || h.Levels == nil {
+		h = &HighScores{Overall: []ScoreEntry{}, Levels: map[string]*LevelScores{}}
+	}
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	h.readOnly = err == ErrNewerVersion
+	return h, err
+}
+
+// Save writes the high score tables to their file
+func (h *HighScores) Save() error {
+	if h.readOnly {
+		return ErrNewerVersion
+	}
+	return write(highScoresFileName, highScoresVersion, h)
+}
+
+// QualifiesOverall returns true if the campaign score gets to the overall table
+func (h *HighScores) QualifiesOverall(score int) bool {
+	return qualifies(h.Overall, score)
+}
+
+// QualifiesLevel returns true if the score made in the level gets to its table
+func (h *HighScores) QualifiesLevel(path string, score int) bool {
+	l, ok := h.Levels[path]
+	return score > 0 && (!ok || qualifies(l.Entries, score))
+}
+
+// AddOverall puts the campaign score into the overall table, it should be called only for qualifying scores
+func (h *HighScores) AddOverall(name string, score int) {
+	h.Overall = insert(h.Overall, ScoreEntry{name, score})
+}
+
+// AddLevel puts the score made in the level into its table, levelName is shown on the leaderboard
+func (h *HighScores) AddLevel(path, levelName, name string, score int) {
+	l, ok := h.Levels[path]
+	if !ok {
+		l = &LevelScores{Entries: []ScoreEntry{}}
+		h.Levels[path] = l
+	}
+	l.Name = levelName
+	l.Entries = insert(l.Entries, ScoreEntry{name, score})
+}
+
+func qualifies(entries []ScoreEntry, score int) bool {
+	if score <= 0 {
+		return false
+	}
+	return len(entries) < constants.HighScoreEntries || score > entries[len(entries)-1].Score
+}
+
+// insert puts the entry below the ones with the same or higher score and drops the lowest one if the table is full
+func insert(entries []ScoreEntry, entry ScoreEntry) []ScoreEntry {
+	i := 0
+	for i < len(entries) && entries[i].Score >= entry.Score {
+		i++
+	}
+	result := append([]ScoreEntry{}, entries[:i]...)
+	result = append(result, entry)
+	result = append(result, entries[i:]...)
+	if len(result) > constants.HighScoreEntries {
+		result = result[:constants.HighScoreEntries]
+	}
+	return result
+}
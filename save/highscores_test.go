@@ -0,0 +1,93 @@
+package save
+
+import (
+	"reflect"
+	"simpleplatformer/constants"
+	"testing"
+)
+
+// fullTable returns a full table with scores going down from the top one by 10
+func fullTable(top int) []ScoreEntry {
+	entries := []ScoreEntry{}
+	for i := 0; i < constants.HighScoreEntries; i++ {
+		entries = append(entries, ScoreEntry{"AAA", top - i*10})
+	}
+	return entries
+}
+
+func TestInsertTieGoesBelow(t *testing.T) {
+	entries := []ScoreEntry{{"AAA", 30}, {"BBB", 20}, {"CCC", 10}}
+	got := insert(entries, ScoreEntry{"NEW", 20})
+	want := []ScoreEntry{{"AAA", 30}, {"BBB", 20}, {"NEW", 20}, {"CCC", 10}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestInsertKeepsOriginal(t *testing.T) {
+	entries := []ScoreEntry{{"AAA", 30}, {"BBB", 10}}
+	insert(entries, ScoreEntry{"NEW", 20})
+	if entries[1] != (ScoreEntry{"BBB", 10}) {
+		t.Errorf("original table was changed: %v", entries)
+	}
+}
+
+func TestInsertTruncates(t *testing.T) {
+	entries := fullTable(100)
+	got := insert(entries, ScoreEntry{"NEW", 95})
+	if len(got) != constants.HighScoreEntries {
+		t.Fatalf("got %v entries, want %v", len(got), constants.HighScoreEntries)
+	}
+	if got[1] != (ScoreEntry{"NEW", 95}) {
+		t.Errorf("got %v at the second place, want the new entry", got[1])
+	}
+	if last := got[len(got)-1]; last.Score != entries[len(entries)-2].Score {
+		t.Errorf("got %v at the last place, the lowest entry should have been dropped", last)
+	}
+}
+
+func TestQualifies(t *testing.T) {
+	full := fullTable(100)
+	lowest := full[len(full)-1].Score
+	tests := []struct {
+		name    string
+		entries []ScoreEntry
+		score   int
+		want    bool
+	}{
+		{"empty table", nil, 1, true},
+		{"zero into empty table", nil, 0, false},
+		{"negative into empty table", nil, -5, false},
+		{"table not full", full[:3], 1, true},
+		{"above the lowest", full, lowest + 1, true},
+		{"tie with the lowest", full, lowest, false},
+		{"below the lowest", full, lowest - 1, false},
+	}
+	for _, tt := range tests {
+		if got := qualifies(tt.entries, tt.score); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQualifiesLevel(t *testing.T) {
+	h := &HighScores{Overall: []ScoreEntry{}, Levels: map[string]*LevelScores{}}
+	if h.QualifiesLevel("level.json", 0) {
+		t.Error("zero score qualifies for a level without a table")
+	}
+	if !h.QualifiesLevel("level.json", 10) {
+		t.Error("score does not qualify for a level without a table")
+	}
+	for _, e := range fullTable(100) {
+		h.AddLevel("level.json", "Level", e.Name, e.Score)
+	}
+	if h.QualifiesLevel("level.json", 30) {
+		t.Error("score tying the lowest qualifies for a full table")
+	}
+	if !h.QualifiesLevel("other.json", 30) {
+		t.Error("tables of other levels are taken into account")
+	}
+	if got := h.Levels["level.json"].Name; got != "Level" {
+		t.Errorf("got level name %v, want Level", got)
+	}
+}
@@ -0,0 +1,26 @@
+package save
+
+import "time"
+
+// Progress is what the player carries over from one level to the next
+type Progress struct {
+	// Level is the index of the level to play next
+	Level int `json:"level"`
+	Lives int `json:"lives"`
+	Score int `json:"score"`
+	// Weapons are the names of the weapons collected so far, Weapon is the equipped one
+	Weapons []string `json:"weapons"`
+	Weapon  string   `json:"weapon"`
+	// Abilities are the names of the movement abilities unlocked so far
+	Abilities []string `json:"abilities"`
+}
+
+// LevelResult is how the player did in a finished level, it's compared with the level records
+type LevelResult struct {
+	// Path is the level file, it identifies the level
+	Path string
+	Time time.Duration
+	// Score is the points made in the level only
+	Score     int
+	Collected int
+}
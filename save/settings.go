@@ -0,0 +1,57 @@
+package save
+
+import (
+	"os"
+	"simpleplatformer/constants"
+)
+
+// settingsVersion is the current version of the settings file format
+const settingsVersion = 2
+
+// settingsMigrations upgrade older settings files, see migration
+var settingsMigrations = []migration{
+	// Version 2 added volume controls
+	func(data map[string]interface{}) {
+		data["musicVolume"] = constants.VolumeSteps
+		data["soundVolume"] = constants.VolumeSteps
+	},
+}
+
+const settingsFileName = "settings.json"
+
+// Settings are shared by all the save slots
+type Settings struct {
+	Fullscreen bool `json:"fullscreen"`
+	// MusicVolume and SoundVolume go from 0 to constants.VolumeSteps
+	MusicVolume int `json:"musicVolume"`
+	SoundVolume int `json:"soundVolume"`
+	// readOnly settings were saved by a newer version of the game, changes to them are not written
+	readOnly bool
+}
+
+func defaultSettings() Settings {
+	return Settings{Fullscreen: false, MusicVolume: constants.VolumeSteps, SoundVolume: constants.VolumeSteps}
+}
+
+// LoadSettings reads the settings, defaults are returned together with the error if they cannot be read
+func LoadSettings() (Settings, error) {
+	s := defaultSettings()
+	err := read(settingsFileName, settingsVersion, settingsMigrations, &s)
+	if os.IsNotExist(err) {
+		return defaultSettings(), nil
+	}
+	if err != nil {
+		s = defaultSettings()
+		s.readOnly = err == ErrNewerVersion
+		return s, err
+	}
+	return s, nil
+}
+
+// Save writes the settings to their file
+func (s Settings) Save() error {
+	if s.readOnly {
+		return ErrNewerVersion
+	}
+	return write(settingsFileName, settingsVersion, s)
+}
@@ -0,0 +1,159 @@
+package save
+
+import (
+	"fmt"
+	"os"
+	"simpleplatformer/constants"
+	"time"
+)
+
+// slotVersion is the current version of the slot file format
+const slotVersion = 3
+
+// slotMigrations upgrade older slot files, see migration
+var slotMigrations = []migration{
+	// Version 2 added the weapon loadout to the progress, the sword was the only weapon before
+	func(data map[string]interface{}) {
+		if progress, ok := data["progress"].(map[string]interface{}); ok {
+			progress["weapons"] = []interface{}{"sword"}
+			progress["weapon"] = "sword"
+		}
+	},
+	// Version 3 made the level high scores count the points of the level only instead of the campaign total,
+	// the old ones cannot be converted so they are reset
+	func(data map[string]interface{}) {
+		if levels, ok := data["levels"].(map[string]interface{}); ok {
+			for _, l := range levels {
+				if record, ok := l.(map[string]interface{}); ok {
+					record["highScore"] = 0
+				}
+			}
+		}
+	},
+}
+
+// LevelRecord holds the best results achieved in a level
+type LevelRecord struct {
+	BestTime      time.Duration `json:"bestTime"`
+	HighScore     int           `json:"highScore"`
+	MostCollected int           `json:"mostCollected"`
+}
+
+// Data is everything stored in a save slot
+type Data struct {
+	// Progress of the campaign being played, there is none if the player has no lives
+	Progress Progress `json:"progress"`
+	// UnlockedLevels is the number of levels the campaign can be started from
+	UnlockedLevels int                    `json:"unlockedLevels"`
+	Levels         map[string]LevelRecord `json:"levels"`
+	HighScore      int                    `json:"highScore"`
+}
+
+// Slot is one of the save files the player can choose from
+type Slot struct {
+	Number int
+	Data   Data
+	// Corrupted slots are shown as such and get overwritten when the player starts playing them
+	Corrupted bool
+	// ReadOnly slots were saved by a newer version of the game, they cannot be played nor overwritten
+	ReadOnly bool
+}
+
+func newData() Data {
+	return Data{UnlockedLevels: 1, Levels: map[string]LevelRecord{}}
+}
+
+func slotFileName(number int) string {
+	return fmt.Sprintf("slot%d.json", number)
+}
+
+// LoadSlots reads all the save slots, missing ones are empty
+func LoadSlots() ([]*Slot, error) {
+	slots := []*Slot{}
+	for i := 1; i <= constants.SaveSlots; i++ {
+		s := &Slot{Number: i, Data: newData()}
+		err := read(slotFileName(i), slotVersion, slotMigrations, &s.Data)
+		if err == ErrCorrupted {
+			s.Data = newData()
+			s.Corrupted = true
+		} else if err == ErrNewerVersion {
+			s.Data = newData()
+			s.ReadOnly = true
+		} else if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if s.Data.Levels == nil {
+			s.Data.Levels = map[string]LevelRecord{}
+		}
+		slots = append(slots, s)
+	}
+	return slots, nil
+}
+
+// Save writes the slot to its file
+func (s *Slot) Save() error {
+	if s.ReadOnly {
+		return ErrNewerVersion
+	}
+	if err := write(slotFileName(s.Number), slotVersion, s.Data); err != nil {
+		return err
+	}
+	s.Corrupted = false
+	return nil
+}
+
+// HasProgress returns true if there is a campaign in progress to continue
+func (s *Slot) HasProgress() bool {
+	return s.Data.Progress.Lives > 0
+}
+
+// Describe returns the summary of the slot shown to the player
+func (s *Slot) Describe() string {
+	switch {
+	case s.Corrupted:
+		return fmt.Sprintf("Slot %d: corrupted", s.Number)
+	case s.ReadOnly:
+		return fmt.Sprintf("Slot %d: saved by a newer version", s.Number)
+	case s.HasProgress():
+		p := s.Data.Progress
+		return fmt.Sprintf("Slot %d: level %d, score %d, lives %d", s.Number, p.Level+1, p.Score, p.Lives)
+	case len(s.Data.Levels) > 0:
+		return fmt.Sprintf("Slot %d: %d levels unlocked, best score %d", s.Number, s.Data.UnlockedLevels, s.Data.HighScore)
+	}
+	return fmt.Sprintf("Slot %d: empty", s.Number)
+}
+
+// CompleteLevel records the level results and the campaign progress made by finishing it,
+// levelCount is the number of levels in the campaign
+func (s *Slot) CompleteLevel(result LevelResult, progress Progress, levelCount int) {
+	record := s.Data.Levels[result.Path]
+	if record.BestTime == 0 || result.Time < record.BestTime {
+		record.BestTime = result.Time
+	}
+	if result.Score > record.HighScore {
+		record.HighScore = result.Score
+	}
+	if result.Collected > record.MostCollected {
+		record.MostCollected = result.Collected
+	}
+	s.Data.Levels[result.Path] = record
+	s.Data.Progress = progress
+	if unlocked := progress.Level + 1; unlocked > s.Data.UnlockedLevels && unlocked <= levelCount {
+		s.Data.UnlockedLevels = unlocked
+	}
+	if progress.Level >= levelCount {
+		s.endCampaign(progress.Score)
+	}
+}
+
+// GameOver ends the campaign with the final score
+func (s *Slot) GameOver(score int) {
+	s.endCampaign(score)
+}
+
+func (s *Slot) endCampaign(score int) {
+	if score > s.Data.HighScore {
+		s.Data.HighScore = score
+	}
+	s.Data.Progress = Progress{}
+}
@@ -0,0 +1,53 @@
+package save
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSlotMigration(t *testing.T) {
+	dir := useTempConfigDir(t)
+	writeEnvelope(t, dir, slotFileName(1), 1, `{
+		"progress": {"level": 2, "lives": 3, "score": 120},
+		"unlockedLevels": 2,
+		"levels": {"assets/levels/level1.json": {"bestTime": 1000, "highScore": 80, "mostCollected": 4}},
+		"highScore": 120
+	}`)
+	slots, err := LoadSlots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := slots[0]
+	if s.Corrupted {
+		t.Fatal("migrated slot is corrupted")
+	}
+	want := Progress{Level: 2, Lives: 3, Score: 120, Weapons: []string{"sword"}, Weapon: "sword"}
+	if !reflect.DeepEqual(s.Data.Progress, want) {
+		t.Errorf("got progress %+v, want %+v", s.Data.Progress, want)
+	}
+	if s.Data.UnlockedLevels != 2 || s.Data.HighScore != 120 {
+		t.Errorf("got unlocked levels %v and high score %v, want 2 and 120", s.Data.UnlockedLevels, s.Data.HighScore)
+	}
+	if r := s.Data.Levels["assets/levels/level1.json"]; r.BestTime != 1000 || r.HighScore != 0 || r.MostCollected != 4 {
+		t.Errorf("got level record %+v", r)
+	}
+}
+
+func TestNewerSlotNotOverwritten(t *testing.T) {
+	dir := useTempConfigDir(t)
+	writeEnvelope(t, dir, slotFileName(1), slotVersion+1, `{"unlockedLevels": 5}`)
+	slots, err := LoadSlots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := slots[0]
+	if !s.ReadOnly || s.Corrupted {
+		t.Fatalf("got read only %v and corrupted %v, want a read only slot", s.ReadOnly, s.Corrupted)
+	}
+	if err := s.Save(); err != ErrNewerVersion {
+		t.Errorf("got save error %v, want %v", err, ErrNewerVersion)
+	}
+	if err := read(slotFileName(1), slotVersion+1, nil, &Data{}); err != nil {
+		t.Errorf("slot file was overwritten: %v", err)
+	}
+}